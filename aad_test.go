@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestVerifyWithAADBindsContext(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("transfer 10")
+	sig, err := SignWithAAD(skHex, msg, []byte("account-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyWithAAD(pubKeyHex, sig, msg, []byte("account-a")); err != nil || !ok {
+		t.Fatalf("same AAD: %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyWithAAD(pubKeyHex, sig, msg, []byte("account-b")); err != nil || ok {
+		t.Fatalf("different AAD: %v, %v; want false", ok, err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, sig, string(msg)); err != nil || ok {
+		t.Fatalf("no AAD: %v, %v; want false", ok, err)
+	}
+}
+
+func TestAADSignatureIsNotRotationLink(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	_, newPubKeyHex := testKeyPair(t)
+	newPub, err := decodeHex(newPubKeyHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without its own domain, an AAD signature over (rotationDomain, pub)
+	// has the same signing root as a rotation link.
+	sig, err := SignWithAAD(skHex, rotationDomain, newPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyRotation(pubKeyHex, newPubKeyHex, sig); err != nil || ok {
+		t.Fatalf("AAD signature accepted as rotation link: %v, %v", ok, err)
+	}
+}
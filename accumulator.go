@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+var ErrAccumulatorMismatch = errors.New("signer set does not match accumulator")
+
+// accumulatorDomain separates accumulator commitments from other hashes of a signer set.
+var accumulatorDomain = []byte("bls-sig signer accumulator")
+
+func accumulatorOf(pubKeyHexes []string) ([32]byte, error) {
+	if len(pubKeyHexes) == 0 {
+		return [32]byte{}, ErrNoPublicKeys
+	}
+	set, err := signerSetKey(pubKeyHexes)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return BuildSigningInput(accumulatorDomain, set[:]), nil
+}
+
+// BuildAccumulator commits to a signer set: the same keys in any order or hex
+// style give the same commitment. It is a hash commitment to the whole set,
+// not an accumulator with per-member witnesses; checking membership means
+// presenting the full set.
+func BuildAccumulator(pubKeyHexes []string) (string, error) {
+	acc, err := accumulatorOf(pubKeyHexes)
+	if err != nil {
+		return "", err
+	}
+	return EncodingEthereum.Encode(acc[:]), nil
+}
+
+// VerifyWithAccumulator checks that claimedPubKeys is the set committed to by
+// accumulatorHex, returning ErrAccumulatorMismatch if not, and then verifies
+// aggSigHex as their aggregate signature over msg.
+func VerifyWithAccumulator(aggSigHex, msg, accumulatorHex string, claimedPubKeys []string) (bool, error) {
+	want, err := decodeHex(accumulatorHex)
+	if err != nil {
+		return false, fmt.Errorf("accumulator: %w", err)
+	}
+	got, err := accumulatorOf(claimedPubKeys)
+	if err != nil {
+		return false, err
+	}
+	if subtle.ConstantTimeCompare(want, got[:]) != 1 {
+		return false, ErrAccumulatorMismatch
+	}
+	return FastAggregateVerify(aggSigHex, msg, claimedPubKeys)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyWithAccumulator(t *testing.T) {
+	const msg = "epoch 9"
+	_, pks, sigs := testSigners(t, 4, msg)
+	acc, err := BuildAccumulator(pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agg := mustAggregate(t, sigs)
+
+	reordered := []string{pks[2], pks[0], pks[3], pks[1]}
+	if ok, err := VerifyWithAccumulator(agg, msg, acc, reordered); err != nil || !ok {
+		t.Fatalf("committed set: ok=%v err=%v", ok, err)
+	}
+
+	// A valid aggregate from a different set must still be rejected.
+	sub := mustAggregate(t, sigs[:3])
+	if ok, err := VerifyWithAccumulator(sub, msg, acc, pks[:3]); !errors.Is(err, ErrAccumulatorMismatch) || ok {
+		t.Fatalf("subset: ok=%v err=%v, want ErrAccumulatorMismatch", ok, err)
+	}
+	_, outsider := testKeyPair(t)
+	swapped := append([]string{outsider}, pks[1:]...)
+	if ok, err := VerifyWithAccumulator(agg, msg, acc, swapped); !errors.Is(err, ErrAccumulatorMismatch) || ok {
+		t.Fatalf("swapped signer: ok=%v err=%v, want ErrAccumulatorMismatch", ok, err)
+	}
+}
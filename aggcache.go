@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// maxCachedAggregates bounds the aggregate public key cache; it is cleared
+// when full.
+const maxCachedAggregates = 4096
+
+// aggregateCache maps a hash of a sorted signer set to its aggregate public key.
+type aggregateCache struct {
+	mu      sync.Mutex
+	entries map[[32]byte]string
+	hits    uint64
+	misses  uint64
+}
+
+var pubKeyAggCache = aggregateCache{entries: make(map[[32]byte]string)}
+
+// signerSetKey hashes the sorted canonical encodings of pubKeyHexes, so the
+// same set in any order or hex style has one key. Duplicates are kept because
+// they change the aggregate.
+func signerSetKey(pubKeyHexes []string) ([32]byte, error) {
+	keys := make([][]byte, len(pubKeyHexes))
+	for i, h := range pubKeyHexes {
+		b, err := decodeHex(h)
+		if err == nil {
+			b, err = canonicalPubKeyBytes(b)
+		}
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("public key %d: %w", i, err)
+		}
+		keys[i] = b
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write(k)
+	}
+	return [32]byte(h.Sum(nil)), nil
+}
+
+// AggregatePublicKeysCached is AggregatePublicKeys with a process-wide cache
+// keyed by the sorted signer set, for committees that recur.
+func AggregatePublicKeysCached(pubKeyHexes []string) (string, error) {
+	if len(pubKeyHexes) == 0 {
+		return "", ErrNoPublicKeys
+	}
+	key, err := signerSetKey(pubKeyHexes)
+	if err != nil {
+		return "", err
+	}
+	c := &pubKeyAggCache
+	c.mu.Lock()
+	agg, ok := c.entries[key]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.mu.Unlock()
+	if ok {
+		return agg, nil
+	}
+
+	agg, err = AggregatePublicKeys(pubKeyHexes)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	if len(c.entries) >= maxCachedAggregates {
+		clear(c.entries)
+	}
+	c.entries[key] = agg
+	c.mu.Unlock()
+	return agg, nil
+}
+
+// AggregateCacheStats returns the hit and miss counts of
+// AggregatePublicKeysCached.
+func AggregateCacheStats() (hits, misses uint64) {
+	pubKeyAggCache.mu.Lock()
+	defer pubKeyAggCache.mu.Unlock()
+	return pubKeyAggCache.hits, pubKeyAggCache.misses
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestAggregatePublicKeysCached(t *testing.T) {
+	_, pks, _ := testSigners(t, 4, "")
+	hits0, misses0 := AggregateCacheStats()
+
+	first, err := AggregatePublicKeysCached(pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reordered := []string{pks[2], pks[0], pks[3], pks[1]}
+	second, err := AggregatePublicKeysCached(reordered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hits, misses := AggregateCacheStats()
+	if hits-hits0 != 1 || misses-misses0 != 1 {
+		t.Fatalf("hits +%d, misses +%d; want +1 each", hits-hits0, misses-misses0)
+	}
+	want, err := AggregatePublicKeys(pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != want || second != want {
+		t.Fatalf("cached aggregates %s, %s; want %s", first, second, want)
+	}
+}
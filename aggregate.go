@@ -0,0 +1,544 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// maxNonParticipantChecks bounds the pairing checks FindNonParticipants will run.
+const maxNonParticipantChecks = 4096
+
+var (
+	ErrNoSignatures      = errors.New("no signatures to aggregate")
+	ErrNoPublicKeys      = errors.New("no public keys")
+	ErrSearchBounded     = errors.New("non-participant search exceeded its work bound")
+	ErrNoMatchingSubset  = errors.New("aggregate matches no subset of the claimed signers")
+	ErrCommitteeMismatch = errors.New("aggregate public key does not match expected committee key")
+	ErrDuplicateMessage  = errors.New("messages are not distinct")
+	ErrDuplicatePubKey   = errors.New("public key appears more than once")
+	ErrUnknownAlias      = errors.New("unknown signer alias")
+	ErrSuspectIndex      = errors.New("suspect index out of range")
+	ErrNotSubset         = errors.New("earlier signer set is not a subset of the later one")
+)
+
+// AggregateSignatures combines hex signatures into a single aggregate signature.
+func AggregateSignatures(sigHexes []string) (string, error) {
+	if len(sigHexes) == 0 {
+		return "", ErrNoSignatures
+	}
+	sigs := make([]common.Signature, len(sigHexes))
+	for i, h := range sigHexes {
+		sig, err := signatureFromHex(h)
+		if err != nil {
+			return "", fmt.Errorf("signature %d: %w", i, err)
+		}
+		sigs[i] = sig
+	}
+	return EncodingEthereum.Encode(bls.AggregateSignatures(sigs).Marshal()), nil
+}
+
+// AggregatePublicKeys sums hex public keys into a single aggregate public key.
+func AggregatePublicKeys(pubKeyHexes []string) (string, error) {
+	if len(pubKeyHexes) == 0 {
+		return "", ErrNoPublicKeys
+	}
+	pks, err := publicKeysFromHex(pubKeyHexes)
+	if err != nil {
+		return "", err
+	}
+	return EncodingEthereum.Encode(bls.AggregateMultiplePubkeys(pks).Marshal()), nil
+}
+
+// AggregateVerify checks an aggregate of signatures where pubKeyHexes[i]
+// signed msgs[i]. If expectedAggPubKeyHex is non-empty, the sum of the public
+// keys must also equal it, otherwise ErrCommitteeMismatch is returned even
+// when the signature itself is valid.
+func AggregateVerify(aggSigHex string, pubKeyHexes, msgs []string, expectedAggPubKeyHex string) (bool, error) {
+	if len(pubKeyHexes) == 0 {
+		return false, ErrNoPublicKeys
+	}
+	if len(pubKeyHexes) != len(msgs) {
+		return false, fmt.Errorf("%w: %d public keys, %d messages", ErrLengthMismatch, len(pubKeyHexes), len(msgs))
+	}
+	sig, err := signatureFromHex(aggSigHex)
+	if err != nil {
+		return false, err
+	}
+	pks, err := publicKeysFromHex(pubKeyHexes)
+	if err != nil {
+		return false, err
+	}
+	if expectedAggPubKeyHex != "" {
+		expected, err := decodeHex(expectedAggPubKeyHex)
+		if err == nil {
+			expected, err = canonicalPubKeyBytes(expected)
+		}
+		if err != nil {
+			return false, fmt.Errorf("expected aggregate public key: %w", err)
+		}
+		if !bytes.Equal(bls.AggregateMultiplePubkeys(pks).Marshal(), expected) {
+			return false, ErrCommitteeMismatch
+		}
+	}
+	roots := make([][32]byte, len(msgs))
+	for i, m := range msgs {
+		roots[i] = signingRoot([]byte(m))
+	}
+	return sig.AggregateVerify(pks, roots), nil
+}
+
+// VerifyDistinct checks an aggregate of signatures where pubKeyHexes[i]
+// signed msgs[i] and every message is different. The aggregate is passed once
+// and paired against all (public key, message) pairs; a repeated message
+// returns ErrDuplicateMessage.
+func VerifyDistinct(aggSigHex string, pubKeyHexes, msgs []string) (bool, error) {
+	seen := make(map[string]int, len(msgs))
+	for i, m := range msgs {
+		if j, dup := seen[m]; dup {
+			return false, fmt.Errorf("%w: messages %d and %d", ErrDuplicateMessage, j, i)
+		}
+		seen[m] = i
+	}
+	return AggregateVerify(aggSigHex, pubKeyHexes, msgs, "")
+}
+
+// FastAggregateVerify checks an aggregate of signatures over one message made
+// by every key in pubKeyHexes.
+func FastAggregateVerify(aggSigHex, msg string, pubKeyHexes []string) (bool, error) {
+	return fastAggregateVerifyRoot(aggSigHex, signingRoot([]byte(msg)), pubKeyHexes)
+}
+
+func fastAggregateVerifyRoot(aggSigHex string, root [32]byte, pubKeyHexes []string) (bool, error) {
+	if len(pubKeyHexes) == 0 {
+		return false, ErrNoPublicKeys
+	}
+	if len(pubKeyHexes) > 1 && allSamePubKey(pubKeyHexes) {
+		return verifyRepeatedSigner(aggSigHex, root, pubKeyHexes[0], len(pubKeyHexes))
+	}
+	sig, err := signatureFromHex(aggSigHex)
+	if err != nil {
+		return false, err
+	}
+	pks, err := publicKeysFromHex(pubKeyHexes)
+	if err != nil {
+		return false, err
+	}
+	return sig.FastAggregateVerify(pks, root), nil
+}
+
+func allSamePubKey(pubKeyHexes []string) bool {
+	first, err := decodeHex(pubKeyHexes[0])
+	if err != nil {
+		return false
+	}
+	for _, h := range pubKeyHexes[1:] {
+		b, err := decodeHex(h)
+		if err != nil || !bytes.Equal(b, first) {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyRepeatedSigner verifies an aggregate of count signatures by one key.
+// Summing count copies of pk is count·pk, so a single scalar multiplication
+// replaces the aggregation; in effect the signer carries weight count.
+func verifyRepeatedSigner(aggSigHex string, root [32]byte, pubKeyHex string, count int) (bool, error) {
+	b, err := decodeHex(aggSigHex)
+	if err != nil {
+		return false, fmt.Errorf("signature: %w", err)
+	}
+	if err := checkSignatureLength(b, SignatureLength); err != nil {
+		return false, err
+	}
+	sig, err := decodeSignaturePoint(b)
+	if err != nil {
+		return false, err
+	}
+	pk, err := blstPublicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	var n [8]byte
+	binary.LittleEndian.PutUint64(n[:], uint64(count))
+	var p blst.P1
+	p.FromAffine(pk)
+	weighted := p.Mult(n[:], 64).ToAffine()
+	return sig.Verify(false, weighted, false, root[:], schemeDSTs[SchemePOP]), nil
+}
+
+// FindNonParticipants returns the indices of claimedPubKeys that did not
+// contribute to aggSigHex. An aggregate only verifies against its exact signer
+// set, so halves of the set can't be tested on their own; instead it tries
+// leaving out one claimed key, then two, and so on, stopping after
+// maxNonParticipantChecks pairings with ErrSearchBounded. If no proper subset
+// verifies it returns ErrNoMatchingSubset.
+func FindNonParticipants(aggSigHex, msg string, claimedPubKeys []string) ([]int, error) {
+	if len(claimedPubKeys) == 0 {
+		return nil, ErrNoPublicKeys
+	}
+	sig, err := signatureFromHex(aggSigHex)
+	if err != nil {
+		return nil, err
+	}
+	pks, err := publicKeysFromHex(claimedPubKeys)
+	if err != nil {
+		return nil, err
+	}
+	root := signingRoot([]byte(msg))
+	if sig.FastAggregateVerify(pks, root) {
+		return []int{}, nil
+	}
+
+	checks := 1
+	n := len(pks)
+	for k := 1; k < n; k++ {
+		excluded := make([]int, k)
+		for i := range excluded {
+			excluded[i] = i
+		}
+		for {
+			if checks >= maxNonParticipantChecks {
+				return nil, ErrSearchBounded
+			}
+			checks++
+			if sig.FastAggregateVerify(withoutIndices(pks, excluded), root) {
+				return append([]int(nil), excluded...), nil
+			}
+			if !nextCombination(excluded, n) {
+				break
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %d claimed", ErrNoMatchingSubset, n)
+}
+
+func withoutIndices(pks []common.PublicKey, excluded []int) []common.PublicKey {
+	out := make([]common.PublicKey, 0, len(pks)-len(excluded))
+	j := 0
+	for i, pk := range pks {
+		if j < len(excluded) && excluded[j] == i {
+			j++
+			continue
+		}
+		out = append(out, pk)
+	}
+	return out
+}
+
+// nextCombination advances c, a sorted k-subset of [0, n), to the next subset in
+// lexicographic order. It reports false once every subset has been visited.
+func nextCombination(c []int, n int) bool {
+	k := len(c)
+	i := k - 1
+	for i >= 0 && c[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	c[i]++
+	for j := i + 1; j < k; j++ {
+		c[j] = c[j-1] + 1
+	}
+	return true
+}
+
+// AggregateUnique aggregates sigHexes after dropping exact duplicates, so a
+// signature submitted twice is only counted once. uniqueCount is the number of
+// signatures that went into the aggregate.
+func AggregateUnique(sigHexes []string) (aggSigHex string, uniqueCount int, err error) {
+	seen := make(map[string]struct{}, len(sigHexes))
+	unique := make([]string, 0, len(sigHexes))
+	for i, h := range sigHexes {
+		b, err := decodeHex(h)
+		if err != nil {
+			return "", 0, fmt.Errorf("signature %d: %w", i, err)
+		}
+		if _, dup := seen[string(b)]; dup {
+			continue
+		}
+		seen[string(b)] = struct{}{}
+		unique = append(unique, h)
+	}
+	aggSigHex, err = AggregateSignatures(unique)
+	if err != nil {
+		return "", 0, err
+	}
+	return aggSigHex, len(unique), nil
+}
+
+// FastAggregateVerifyWithFallback is FastAggregateVerify that, only when the
+// aggregate fails, runs the more expensive FindNonParticipants search to name
+// the signers whose contribution is missing. An invalid aggregate that no
+// subset explains, or whose search hits its work bound, returns false with nil
+// badSigners and no error.
+func FastAggregateVerifyWithFallback(aggSigHex, msg string, pubKeyHexes []string) (valid bool, badSigners []int, err error) {
+	valid, err = FastAggregateVerify(aggSigHex, msg, pubKeyHexes)
+	if err != nil || valid {
+		return valid, nil, err
+	}
+	badSigners, err = FindNonParticipants(aggSigHex, msg, pubKeyHexes)
+	if errors.Is(err, ErrNoMatchingSubset) || errors.Is(err, ErrSearchBounded) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return false, badSigners, nil
+}
+
+// AggregateClaim is an aggregate signature together with the signers it
+// claims to cover.
+type AggregateClaim struct {
+	Signers            []string `json:"signers"`
+	AggregateSignature string   `json:"aggregate_signature"`
+}
+
+// VerifyConsistentAggregates checks each claim independently against the
+// shared msg, so aggregates from several sources can be confirmed to cover the
+// same message before they are merged.
+func VerifyConsistentAggregates(aggs []AggregateClaim, msg string) ([]bool, error) {
+	results := make([]bool, len(aggs))
+	for i, a := range aggs {
+		ok, err := FastAggregateVerify(a.AggregateSignature, msg, a.Signers)
+		if err != nil {
+			return nil, fmt.Errorf("aggregate %d: %w", i, err)
+		}
+		results[i] = ok
+	}
+	return results, nil
+}
+
+// VerifyAggregateMatchesIndividuals re-aggregates individualSigHexes and
+// reports whether the result is byte-identical to aggSigHex, catching an
+// aggregate built from a different set of signatures than claimed.
+func VerifyAggregateMatchesIndividuals(aggSigHex string, individualSigHexes []string) (bool, error) {
+	want, err := decodeHex(aggSigHex)
+	if err != nil {
+		return false, fmt.Errorf("aggregate signature: %w", err)
+	}
+	got, err := AggregateSignatures(individualSigHexes)
+	if err != nil {
+		return false, err
+	}
+	gotBytes, err := decodeHex(got)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(want, gotBytes), nil
+}
+
+// AggregateBundle carries everything needed to verify a same-message
+// aggregate: the aggregate signature and public key, the individual signers
+// and the message they signed.
+type AggregateBundle struct {
+	AggregateSignature string   `json:"aggregate_signature"`
+	AggregatePublicKey string   `json:"aggregate_pubkey"`
+	Signers            []string `json:"signers"`
+	Message            string   `json:"message"`
+}
+
+// MakeAggregateBundle aggregates sigHexes and pubKeyHexes, where each key
+// signed msg, into an AggregateBundle.
+func MakeAggregateBundle(sigHexes, pubKeyHexes []string, msg string) (AggregateBundle, error) {
+	if len(sigHexes) != len(pubKeyHexes) {
+		return AggregateBundle{}, fmt.Errorf("%w: %d signatures, %d public keys", ErrLengthMismatch, len(sigHexes), len(pubKeyHexes))
+	}
+	aggSig, err := AggregateSignatures(sigHexes)
+	if err != nil {
+		return AggregateBundle{}, err
+	}
+	aggPub, err := AggregatePublicKeys(pubKeyHexes)
+	if err != nil {
+		return AggregateBundle{}, err
+	}
+	return AggregateBundle{
+		AggregateSignature: aggSig,
+		AggregatePublicKey: aggPub,
+		Signers:            append([]string(nil), pubKeyHexes...),
+		Message:            msg,
+	}, nil
+}
+
+// Verify checks that the signers sum to the bundled aggregate public key and
+// that the aggregate signature is valid for them over the message. A bundle
+// whose aggregate key disagrees with its signers returns ErrCommitteeMismatch.
+func (b AggregateBundle) Verify() (bool, error) {
+	aggPub, err := AggregatePublicKeys(b.Signers)
+	if err != nil {
+		return false, err
+	}
+	want, err := decodeHex(b.AggregatePublicKey)
+	if err != nil {
+		return false, fmt.Errorf("aggregate public key: %w", err)
+	}
+	got, err := decodeHex(aggPub)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(want, got) {
+		return false, ErrCommitteeMismatch
+	}
+	return FastAggregateVerify(b.AggregateSignature, b.Message, b.Signers)
+}
+
+// VerifyExactSignerSet checks that aggSigHex is the aggregate of signatures
+// over msg from exactly claimedPubKeys: it verifies against the sum of the
+// claimed keys, so an aggregate padded with any extra signer, or missing one,
+// fails. This relies on every signer having signed the same message and on
+// the keys having proofs of possession; it cannot detect a rogue key.
+// Repeating a key in claimedPubKeys returns ErrDuplicatePubKey.
+func VerifyExactSignerSet(aggSigHex, msg string, claimedPubKeys []string) (bool, error) {
+	canonical, err := CanonicalizePubKeys(claimedPubKeys)
+	if err != nil {
+		return false, err
+	}
+	if len(canonical) != len(claimedPubKeys) {
+		return false, ErrDuplicatePubKey
+	}
+	aggPub, err := AggregatePublicKeys(canonical)
+	if err != nil {
+		return false, err
+	}
+	return VerifySignature(aggPub, aggSigHex, msg)
+}
+
+// VerifyAndDiff verifies aggSigHex over msg against actualPubKeys and reports
+// which expectedPubKeys are missing from the actual set and which actual keys
+// were not expected. Both lists hold canonical hex and are sorted; the diff is
+// computed even when the signature is invalid.
+func VerifyAndDiff(aggSigHex, msg string, actualPubKeys, expectedPubKeys []string) (valid bool, missing []string, extra []string, err error) {
+	actual, err := CanonicalizePubKeys(actualPubKeys)
+	if err != nil {
+		return false, nil, nil, fmt.Errorf("actual: %w", err)
+	}
+	expected, err := CanonicalizePubKeys(expectedPubKeys)
+	if err != nil {
+		return false, nil, nil, fmt.Errorf("expected: %w", err)
+	}
+	valid, err = FastAggregateVerify(aggSigHex, msg, actualPubKeys)
+	if err != nil {
+		return false, nil, nil, err
+	}
+	return valid, sortedDifference(expected, actual), sortedDifference(actual, expected), nil
+}
+
+// sortedDifference returns the elements of sorted a that are not in sorted b.
+func sortedDifference(a, b []string) []string {
+	var out []string
+	j := 0
+	for _, s := range a {
+		for j < len(b) && b[j] < s {
+			j++
+		}
+		if j == len(b) || b[j] != s {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// VerifyByAlias resolves aliases to public keys through aliasMap and checks
+// aggSigHex as their aggregate signature over msg. A name missing from the map
+// returns ErrUnknownAlias.
+func VerifyByAlias(aliasMap map[string]string, aggSigHex, msg string, aliases []string) (bool, error) {
+	pks := make([]string, len(aliases))
+	for i, a := range aliases {
+		pk, ok := aliasMap[a]
+		if !ok {
+			return false, fmt.Errorf("%w: %q", ErrUnknownAlias, a)
+		}
+		pks[i] = pk
+	}
+	return FastAggregateVerify(aggSigHex, msg, pks)
+}
+
+// ProveNonParticipation reports whether aggSigHex verifies over msg against
+// fullCommittee with the member at suspectIndex left out, which shows the
+// aggregate holds no contribution from that member. Like any aggregate check
+// it assumes every committee key has a proof of possession.
+func ProveNonParticipation(aggSigHex, msg string, fullCommittee []string, suspectIndex int) (bool, error) {
+	if suspectIndex < 0 || suspectIndex >= len(fullCommittee) {
+		return false, fmt.Errorf("%w: %d of %d", ErrSuspectIndex, suspectIndex, len(fullCommittee))
+	}
+	if len(fullCommittee) < 2 {
+		return false, ErrNoPublicKeys
+	}
+	sig, err := signatureFromHex(aggSigHex)
+	if err != nil {
+		return false, err
+	}
+	pks, err := publicKeysFromHex(fullCommittee)
+	if err != nil {
+		return false, err
+	}
+	return sig.FastAggregateVerify(withoutIndices(pks, []int{suspectIndex}), signingRoot([]byte(msg))), nil
+}
+
+// VerifyAggregateDelta checks that aggBHex extends aggAHex, both over msg, by
+// exactly the signers in signersB but not signersA: it subtracts aggregate A
+// from aggregate B and verifies the difference against the added keys. It
+// says nothing about aggregate A itself, so verify that separately. signersA
+// must be a subset of signersB, or ErrNotSubset is returned; repeating a key
+// in either set returns ErrDuplicatePubKey. An empty delta is valid only if
+// the two aggregates are equal.
+func VerifyAggregateDelta(aggAHex, aggBHex, msg string, signersA, signersB []string) (bool, error) {
+	a, err := CanonicalizePubKeys(signersA)
+	if err != nil {
+		return false, fmt.Errorf("signers A: %w", err)
+	}
+	b, err := CanonicalizePubKeys(signersB)
+	if err != nil {
+		return false, fmt.Errorf("signers B: %w", err)
+	}
+	if len(a) != len(signersA) || len(b) != len(signersB) {
+		return false, ErrDuplicatePubKey
+	}
+	if len(sortedDifference(a, b)) > 0 {
+		return false, ErrNotSubset
+	}
+	sigA, err := signaturePointFromHex(aggAHex)
+	if err != nil {
+		return false, fmt.Errorf("aggregate A: %w", err)
+	}
+	sigB, err := signaturePointFromHex(aggBHex)
+	if err != nil {
+		return false, fmt.Errorf("aggregate B: %w", err)
+	}
+	added := sortedDifference(b, a)
+	if len(added) == 0 {
+		return sigA.Equals(sigB), nil
+	}
+
+	var delta blst.P2
+	delta.FromAffine(sigB)
+	delta.SubAssign(sigA)
+	aggPub, err := AggregatePublicKeys(added)
+	if err != nil {
+		return false, err
+	}
+	pk, err := blstPublicKeyFromHex(aggPub)
+	if err != nil {
+		return false, err
+	}
+	root := signingRoot([]byte(msg))
+	return delta.ToAffine().Verify(false, pk, false, root[:], schemeDSTs[SchemePOP]), nil
+}
+
+func signaturePointFromHex(sigHex string) (*blst.P2Affine, error) {
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	if err := checkSignatureLength(b, SignatureLength); err != nil {
+		return nil, err
+	}
+	return decodeSignaturePoint(b)
+}
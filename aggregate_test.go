@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testSigners returns n fresh key pairs and their signatures over msg.
+func testSigners(t *testing.T, n int, msg string) (sks, pks, sigs []string) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		sk, pk := testKeyPair(t)
+		sks = append(sks, sk)
+		pks = append(pks, pk)
+		sigs = append(sigs, mustSign(t, sk, msg))
+	}
+	return sks, pks, sigs
+}
+
+func mustAggregate(t *testing.T, sigs []string) string {
+	t.Helper()
+	agg, err := AggregateSignatures(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return agg
+}
+
+func TestFindNonParticipants(t *testing.T) {
+	_, pks, sigs := testSigners(t, 8, "block")
+	const absent = 5
+	signed := append(append([]string(nil), sigs[:absent]...), sigs[absent+1:]...)
+	agg := mustAggregate(t, signed)
+
+	got, err := FindNonParticipants(agg, "block", pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{absent}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindNonParticipants = %v, want %v", got, want)
+	}
+
+	got, err = FindNonParticipants(mustAggregate(t, sigs), "block", pks)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("full participation: got %v, %v; want none", got, err)
+	}
+}
+
+func TestAggregateVerifyCommitteeMismatch(t *testing.T) {
+	_, pks, sigs := testSigners(t, 3, "attest")
+	agg := mustAggregate(t, sigs)
+	msgs := []string{"attest", "attest", "attest"}
+	committee, err := AggregatePublicKeys(pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := AggregateVerify(agg, pks, msgs, committee); err != nil || !ok {
+		t.Fatalf("matching committee: %v, %v; want true", ok, err)
+	}
+	wrong, err := AggregatePublicKeys(pks[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := AggregateVerify(agg, pks, msgs, wrong); !errors.Is(err, ErrCommitteeMismatch) || ok {
+		t.Fatalf("wrong committee: %v, %v; want ErrCommitteeMismatch", ok, err)
+	}
+}
+
+func TestAggregateUnique(t *testing.T) {
+	_, _, sigs := testSigners(t, 2, "vote")
+	a, b := sigs[0], sigs[1]
+	agg, n, err := AggregateUnique([]string{a, a, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("uniqueCount = %d, want 2", n)
+	}
+	if want := mustAggregate(t, []string{a, b}); agg != want {
+		t.Fatalf("aggregate of [A A B] = %s, want aggregate of [A B] %s", agg, want)
+	}
+}
+
+func TestFastAggregateVerifyWithFallback(t *testing.T) {
+	_, pks, sigs := testSigners(t, 4, "block")
+	valid, bad, err := FastAggregateVerifyWithFallback(mustAggregate(t, sigs[1:]), "block", pks)
+	if err != nil || valid || !reflect.DeepEqual(bad, []int{0}) {
+		t.Fatalf("missing signer 0: %v, %v, %v; want false, [0], nil", valid, bad, err)
+	}
+
+	// Signed over a different message, so no subset of the claimed signers
+	// explains the aggregate.
+	_, _, other := testSigners(t, 1, "other block")
+	valid, bad, err = FastAggregateVerifyWithFallback(other[0], "block", pks)
+	if err != nil || valid || bad != nil {
+		t.Fatalf("unexplained aggregate: %v, %v, %v; want false, nil, nil", valid, bad, err)
+	}
+	if _, err := FindNonParticipants(other[0], "block", pks); !errors.Is(err, ErrNoMatchingSubset) {
+		t.Fatalf("FindNonParticipants: err = %v, want ErrNoMatchingSubset", err)
+	}
+}
+
+func TestVerifyConsistentAggregates(t *testing.T) {
+	_, pksA, sigsA := testSigners(t, 2, "root")
+	_, pksB, sigsB := testSigners(t, 2, "other root")
+	claims := []AggregateClaim{
+		{Signers: pksA, AggregateSignature: mustAggregate(t, sigsA)},
+		{Signers: pksB, AggregateSignature: mustAggregate(t, sigsB)},
+	}
+	got, err := VerifyConsistentAggregates(claims, "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []bool{true, false}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("VerifyConsistentAggregates = %v, want %v", got, want)
+	}
+}
+
+// naiveFastAggregateVerify aggregates every key, repeated or not.
+func naiveFastAggregateVerify(t *testing.T, aggSigHex, msg string, pubKeyHexes []string) bool {
+	t.Helper()
+	sig, err := signatureFromHex(aggSigHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pks, err := publicKeysFromHex(pubKeyHexes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig.FastAggregateVerify(pks, signingRoot([]byte(msg)))
+}
+
+func TestFastAggregateVerifyRepeatedSigner(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "demo")
+	pks := []string{pubKeyHex, pubKeyHex, pubKeyHex, pubKeyHex, pubKeyHex}
+	five := mustAggregate(t, []string{sig, sig, sig, sig, sig})
+	four := mustAggregate(t, []string{sig, sig, sig, sig})
+
+	for _, agg := range []string{five, four} {
+		got, err := FastAggregateVerify(agg, "demo", pks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := naiveFastAggregateVerify(t, agg, "demo", pks); got != want {
+			t.Fatalf("repeated-signer path = %v, naive aggregation = %v", got, want)
+		}
+		if want := agg == five; got != want {
+			t.Fatalf("FastAggregateVerify = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestVerifyAggregateMatchesIndividuals(t *testing.T) {
+	_, _, sigs := testSigners(t, 3, "root")
+	agg := mustAggregate(t, sigs)
+	if ok, err := VerifyAggregateMatchesIndividuals(agg, sigs); err != nil || !ok {
+		t.Fatalf("same signatures: %v, %v; want true", ok, err)
+	}
+	_, _, other := testSigners(t, 1, "root")
+	substituted := []string{sigs[0], other[0], sigs[2]}
+	if ok, err := VerifyAggregateMatchesIndividuals(agg, substituted); err != nil || ok {
+		t.Fatalf("substituted signature: %v, %v; want false", ok, err)
+	}
+}
+
+func TestVerifyDistinct(t *testing.T) {
+	var pks, msgs, sigs []string
+	for _, m := range []string{"alpha", "beta", "gamma"} {
+		sk, pk := testKeyPair(t)
+		pks = append(pks, pk)
+		msgs = append(msgs, m)
+		sigs = append(sigs, mustSign(t, sk, m))
+	}
+	agg := mustAggregate(t, sigs)
+	if ok, err := VerifyDistinct(agg, pks, msgs); err != nil || !ok {
+		t.Fatalf("distinct messages: %v, %v; want true", ok, err)
+	}
+	swapped := []string{msgs[1], msgs[0], msgs[2]}
+	if ok, err := VerifyDistinct(agg, pks, swapped); err != nil || ok {
+		t.Fatalf("messages swapped between signers: %v, %v; want false", ok, err)
+	}
+	if _, err := VerifyDistinct(agg, pks, []string{"alpha", "beta", "alpha"}); !errors.Is(err, ErrDuplicateMessage) {
+		t.Fatalf("repeated message: err = %v, want ErrDuplicateMessage", err)
+	}
+}
+
+func TestAggregateBundleJSONRoundTrip(t *testing.T) {
+	_, pks, sigs := testSigners(t, 3, "bundle")
+	bundle, err := MakeAggregateBundle(sigs, pks, "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded AggregateBundle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := decoded.Verify(); err != nil || !ok {
+		t.Fatalf("decoded bundle: %v, %v; want true", ok, err)
+	}
+	decoded.Signers = decoded.Signers[:2]
+	if _, err := decoded.Verify(); !errors.Is(err, ErrCommitteeMismatch) {
+		t.Fatalf("bundle missing a signer: err = %v, want ErrCommitteeMismatch", err)
+	}
+}
+
+func TestVerifyExactSignerSet(t *testing.T) {
+	_, pks, sigs := testSigners(t, 4, "exact")
+	claimed := pks[:3]
+	if ok, err := VerifyExactSignerSet(mustAggregate(t, sigs[:3]), "exact", claimed); err != nil || !ok {
+		t.Fatalf("exact set: %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyExactSignerSet(mustAggregate(t, sigs), "exact", claimed); err != nil || ok {
+		t.Fatalf("extra signer: %v, %v; want false", ok, err)
+	}
+	if _, err := VerifyExactSignerSet(mustAggregate(t, sigs[:3]), "exact", []string{pks[0], pks[1], pks[0]}); !errors.Is(err, ErrDuplicatePubKey) {
+		t.Fatalf("repeated key: err = %v, want ErrDuplicatePubKey", err)
+	}
+}
+
+func TestVerifyAndDiff(t *testing.T) {
+	_, pks, sigs := testSigners(t, 4, "duty")
+	expected := pks[:3]
+	actual := []string{pks[0], pks[1], pks[3]}
+	agg := mustAggregate(t, []string{sigs[0], sigs[1], sigs[3]})
+
+	valid, missing, extra, err := VerifyAndDiff(agg, "duty", actual, expected)
+	if err != nil || !valid {
+		t.Fatalf("VerifyAndDiff = %v, %v; want valid", valid, err)
+	}
+	if !reflect.DeepEqual(missing, []string{pks[2]}) || !reflect.DeepEqual(extra, []string{pks[3]}) {
+		t.Fatalf("missing %v, extra %v; want [%s], [%s]", missing, extra, pks[2], pks[3])
+	}
+}
+
+func TestVerifyByAlias(t *testing.T) {
+	_, pks, sigs := testSigners(t, 3, "release v2")
+	aliases := map[string]string{"alice": pks[0], "bob": pks[1], "carol": pks[2]}
+	agg := mustAggregate(t, []string{sigs[0], sigs[2]})
+	if ok, err := VerifyByAlias(aliases, agg, "release v2", []string{"alice", "carol"}); err != nil || !ok {
+		t.Fatalf("alice and carol: %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyByAlias(aliases, agg, "release v2", []string{"alice", "bob"}); err != nil || ok {
+		t.Fatalf("alice and bob: %v, %v; want false", ok, err)
+	}
+	if _, err := VerifyByAlias(aliases, agg, "release v2", []string{"alice", "mallory"}); !errors.Is(err, ErrUnknownAlias) {
+		t.Fatalf("unknown alias: err = %v, want ErrUnknownAlias", err)
+	}
+}
+
+func TestProveNonParticipation(t *testing.T) {
+	_, pks, sigs := testSigners(t, 4, "slot 5")
+	const absent = 2
+	agg := mustAggregate(t, []string{sigs[0], sigs[1], sigs[3]})
+	if ok, err := ProveNonParticipation(agg, "slot 5", pks, absent); err != nil || !ok {
+		t.Fatalf("genuine non-participant: %v, %v; want true", ok, err)
+	}
+	if ok, err := ProveNonParticipation(agg, "slot 5", pks, 1); err != nil || ok {
+		t.Fatalf("participant: %v, %v; want false", ok, err)
+	}
+	if _, err := ProveNonParticipation(agg, "slot 5", pks, 4); !errors.Is(err, ErrSuspectIndex) {
+		t.Fatalf("index out of range: err = %v, want ErrSuspectIndex", err)
+	}
+}
+
+func TestVerifyAggregateDelta(t *testing.T) {
+	const msg = "slot 12"
+	_, pks, sigs := testSigners(t, 4, msg)
+	aggA := mustAggregate(t, sigs[:3])
+	aggB := mustAggregate(t, sigs)
+
+	if ok, err := VerifyAggregateDelta(aggA, aggB, msg, pks[:3], pks); err != nil || !ok {
+		t.Fatalf("B adds signer 3: ok=%v err=%v", ok, err)
+	}
+	// Claiming a different added signer fails.
+	claimed := []string{pks[0], pks[1], pks[3]}
+	if ok, err := VerifyAggregateDelta(mustAggregate(t, sigs[:2]), aggB, msg, pks[:2], claimed); err != nil || ok {
+		t.Fatalf("wrong delta: ok=%v err=%v", ok, err)
+	}
+	if _, err := VerifyAggregateDelta(aggA, aggB, msg, pks[:3], pks[1:]); !errors.Is(err, ErrNotSubset) {
+		t.Fatalf("A not within B: err = %v, want ErrNotSubset", err)
+	}
+	if ok, err := VerifyAggregateDelta(aggA, aggA, msg, pks[:3], pks[:3]); err != nil || !ok {
+		t.Fatalf("empty delta: ok=%v err=%v", ok, err)
+	}
+}
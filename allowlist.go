@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotAllowlisted   = errors.New("public key is not on the allowlist")
+	ErrAllowlistMissing = errors.New("allowlist has never been fetched")
+)
+
+// maxAllowlistBytes bounds the size of a fetched allowlist.
+const maxAllowlistBytes = 4 << 20
+
+// RemoteAllowlist is a set of public keys fetched from a URL serving a JSON
+// array of hex keys, refreshed once the TTL has passed. If a refresh fails
+// the last good list stays in use and the fetch is retried after another TTL.
+// It is safe for concurrent use.
+type RemoteAllowlist struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]struct{}
+	lastAttempt time.Time
+	lastErr     error
+}
+
+// NewRemoteAllowlist returns an allowlist for url that refreshes every ttl.
+// Nothing is fetched until first use.
+func NewRemoteAllowlist(url string, ttl time.Duration) *RemoteAllowlist {
+	return &RemoteAllowlist{url: url, ttl: ttl, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Contains reports whether pubKeyHex is on the list, refreshing it first if
+// the TTL has passed. It returns an error only if no fetch has ever succeeded.
+func (a *RemoteAllowlist) Contains(pubKeyHex string) (bool, error) {
+	b, err := decodeHex(pubKeyHex)
+	if err == nil {
+		b, err = canonicalPubKeyBytes(b)
+	}
+	if err != nil {
+		return false, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if time.Since(a.lastAttempt) >= a.ttl {
+		a.lastAttempt = time.Now()
+		keys, err := a.fetch()
+		a.lastErr = err
+		if err == nil {
+			a.keys = keys
+		}
+	}
+	if a.keys == nil {
+		return false, fmt.Errorf("%w: %v", ErrAllowlistMissing, a.lastErr)
+	}
+	_, ok := a.keys[EncodingEthereum.Encode(b)]
+	return ok, nil
+}
+
+// LastError returns the error from the most recent fetch, or nil if it
+// succeeded.
+func (a *RemoteAllowlist) LastError() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastErr
+}
+
+func (a *RemoteAllowlist) fetch() (map[string]struct{}, error) {
+	resp, err := a.client.Get(a.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch allowlist: %s", resp.Status)
+	}
+	var list []string
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxAllowlistBytes)).Decode(&list); err != nil {
+		return nil, fmt.Errorf("parse allowlist: %w", err)
+	}
+	keys := make(map[string]struct{}, len(list))
+	for i, h := range list {
+		b, err := decodeHex(h)
+		if err == nil {
+			b, err = canonicalPubKeyBytes(b)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("allowlist entry %d: %w", i, err)
+		}
+		keys[EncodingEthereum.Encode(b)] = struct{}{}
+	}
+	return keys, nil
+}
+
+// VerifyFromAllowlist verifies sigHex over msg like VerifySignature, but only
+// for a public key on allowlist; any other key returns ErrNotAllowlisted.
+func VerifyFromAllowlist(allowlist *RemoteAllowlist, pubKeyHex, sigHex, msg string) (bool, error) {
+	ok, err := allowlist.Contains(pubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, ErrNotAllowlisted
+	}
+	return VerifySignature(pubKeyHex, sigHex, msg)
+}
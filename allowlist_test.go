@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerifyFromAllowlist(t *testing.T) {
+	sk0, pk0 := testKeyPair(t)
+	sk1, pk1 := testKeyPair(t)
+	var served atomic.Pointer[[]string] // nil makes the server fail
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		list := served.Load()
+		if list == nil {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(*list)
+	}))
+	defer srv.Close()
+
+	// A TTL of zero refetches on every call.
+	a := NewRemoteAllowlist(srv.URL, 0)
+	sig0, sig1 := mustSign(t, sk0, "hello"), mustSign(t, sk1, "hello")
+	if _, err := VerifyFromAllowlist(a, pk0, sig0, "hello"); !errors.Is(err, ErrAllowlistMissing) {
+		t.Fatalf("never fetched: err = %v, want ErrAllowlistMissing", err)
+	}
+
+	served.Store(&[]string{pk0})
+	if ok, err := VerifyFromAllowlist(a, pk0, sig0, "hello"); err != nil || !ok {
+		t.Fatalf("listed key: ok=%v err=%v", ok, err)
+	}
+	if _, err := VerifyFromAllowlist(a, pk1, sig1, "hello"); !errors.Is(err, ErrNotAllowlisted) {
+		t.Fatalf("unlisted key: err = %v, want ErrNotAllowlisted", err)
+	}
+
+	// A failed refresh keeps the last good list.
+	served.Store(nil)
+	if ok, err := VerifyFromAllowlist(a, pk0, sig0, "hello"); err != nil || !ok {
+		t.Fatalf("after failed refresh: ok=%v err=%v", ok, err)
+	}
+	if a.LastError() == nil {
+		t.Fatal("failed refresh not recorded")
+	}
+
+	served.Store(&[]string{pk0, pk1})
+	if ok, err := VerifyFromAllowlist(a, pk1, sig1, "hello"); err != nil || !ok {
+		t.Fatalf("newly listed key: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestRemoteAllowlistTTL(t *testing.T) {
+	_, pk := testKeyPair(t)
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode([]string{pk})
+	}))
+	defer srv.Close()
+
+	a := NewRemoteAllowlist(srv.URL, time.Hour)
+	for i := 0; i < 3; i++ {
+		if ok, err := a.Contains(pk); err != nil || !ok {
+			t.Fatalf("call %d: ok=%v err=%v", i, ok, err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("fetched %d times within the TTL, want 1", got)
+	}
+}
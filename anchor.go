@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidAnchor = errors.New("invalid anchored signature")
+
+// anchorDomain separates anchored signatures from signatures over ordinary
+// messages.
+var anchorDomain = []byte("bls-sig block anchor")
+
+// anchoredBlobLength is the size of an anchored blob: the signature, the
+// 32-byte block hash and the big-endian block number.
+const anchoredBlobLength = SignatureLength + 32 + 8
+
+func anchorRoot(msg, blockHash []byte, blockNumber uint64) [32]byte {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], blockNumber)
+	return BuildSigningInput(anchorDomain, blockHash, n[:], msg)
+}
+
+func decodeBlockHash(blockHashHex string) ([]byte, error) {
+	h, err := decodeHex(blockHashHex)
+	if err != nil {
+		return nil, fmt.Errorf("block hash: %w", err)
+	}
+	if len(h) != 32 {
+		return nil, fmt.Errorf("%w: block hash is %d bytes, want 32", ErrInvalidAnchor, len(h))
+	}
+	return h, nil
+}
+
+// SignAnchored signs msg together with the hash and number of a recent block.
+// Because the block hash can't be known in advance, the signature shows it
+// was made no earlier than that block; publishing it in a later block bounds
+// it from the other side. Pass the result to AnchorSignature.
+func SignAnchored(skHex string, msg []byte, blockHashHex string, blockNumber uint64) (string, error) {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return "", err
+	}
+	h, err := decodeBlockHash(blockHashHex)
+	if err != nil {
+		return "", err
+	}
+	return signRoot(skHex, anchorRoot(msg, h, blockNumber))
+}
+
+// AnchorSignature packs a SignAnchored signature with the block it covers
+// into one blob for VerifyAnchor. It does not check the signature.
+func AnchorSignature(sigHex string, blockHashHex string, blockNumber uint64) (anchoredBlobHex string, err error) {
+	sig, err := decodeHex(sigHex)
+	if err != nil {
+		return "", fmt.Errorf("signature: %w", err)
+	}
+	if err := checkSignatureLength(sig, SignatureLength); err != nil {
+		return "", err
+	}
+	h, err := decodeBlockHash(blockHashHex)
+	if err != nil {
+		return "", err
+	}
+	blob := make([]byte, 0, anchoredBlobLength)
+	blob = append(blob, sig...)
+	blob = append(blob, h...)
+	blob = binary.BigEndian.AppendUint64(blob, blockNumber)
+	return EncodingEthereum.Encode(blob), nil
+}
+
+// VerifyAnchor checks an AnchorSignature blob over msg and returns the block
+// number it is anchored to. Changing the block hash or number in the blob
+// invalidates it.
+func VerifyAnchor(pubKeyHex, anchoredBlobHex string, msg []byte) (blockNumber uint64, valid bool, err error) {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return 0, false, err
+	}
+	blob, err := decodeHex(anchoredBlobHex)
+	if err != nil {
+		return 0, false, fmt.Errorf("anchored blob: %w", err)
+	}
+	if len(blob) != anchoredBlobLength {
+		return 0, false, fmt.Errorf("%w: blob is %d bytes, want %d", ErrInvalidAnchor, len(blob), anchoredBlobLength)
+	}
+	sig, h := blob[:SignatureLength], blob[SignatureLength:SignatureLength+32]
+	blockNumber = binary.BigEndian.Uint64(blob[SignatureLength+32:])
+	valid, err = verifyRoot(pubKeyHex, EncodingEthereum.Encode(sig), anchorRoot(msg, h, blockNumber))
+	if err != nil {
+		return 0, false, err
+	}
+	return blockNumber, valid, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestVerifyAnchor(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("report digest")
+	blockHash := "0x" + strings.Repeat("ab", 32)
+	const blockNumber = 19_000_000
+
+	sig, err := SignAnchored(skHex, msg, blockHash, blockNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobHex, err := AnchorSignature(sig, blockHash, blockNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, ok, err := VerifyAnchor(pubKeyHex, blobHex, msg)
+	if err != nil || !ok || n != blockNumber {
+		t.Fatalf("anchored signature: block %d, ok=%v err=%v", n, ok, err)
+	}
+	if _, ok, err := VerifyAnchor(pubKeyHex, blobHex, []byte("other digest")); err != nil || ok {
+		t.Fatalf("other message: ok=%v err=%v", ok, err)
+	}
+
+	blob, err := decodeHex(blobHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary.BigEndian.PutUint64(blob[SignatureLength+32:], blockNumber-1)
+	if n, ok, err := VerifyAnchor(pubKeyHex, EncodingEthereum.Encode(blob), msg); err != nil || ok {
+		t.Fatalf("tampered block number %d: ok=%v err=%v", n, ok, err)
+	}
+}
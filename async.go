@@ -0,0 +1,25 @@
+package main
+
+// VerifyFuture is the pending result of VerifyAsync.
+type VerifyFuture struct {
+	done  chan struct{}
+	valid bool
+	err   error
+}
+
+// VerifyAsync starts VerifySignature in a goroutine and returns immediately.
+func VerifyAsync(pubKeyHex, sigHex, msg string) *VerifyFuture {
+	f := &VerifyFuture{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		f.valid, f.err = VerifySignature(pubKeyHex, sigHex, msg)
+	}()
+	return f
+}
+
+// Wait blocks until the verification finishes and returns its result. It may
+// be called any number of times.
+func (f *VerifyFuture) Wait() (bool, error) {
+	<-f.done
+	return f.valid, f.err
+}
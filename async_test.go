@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestVerifyAsync(t *testing.T) {
+	entries := testTuples(t, 4)
+	entries[2].Message = "tampered"
+	futures := make([]*VerifyFuture, len(entries))
+	for i, e := range entries {
+		futures[i] = VerifyAsync(e.PubKey, e.Signature, e.Message)
+	}
+	for i, f := range futures {
+		ok, err := f.Wait()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := i != 2; ok != want {
+			t.Fatalf("future %d = %v, want %v", i, ok, want)
+		}
+		if again, _ := f.Wait(); again != ok {
+			t.Fatalf("second Wait on future %d changed the result", i)
+		}
+	}
+}
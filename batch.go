@@ -0,0 +1,386 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var (
+	ErrLengthMismatch    = errors.New("batch inputs have different lengths")
+	ErrNoValidSignatures = errors.New("no valid signatures")
+)
+
+// VerifyTuple is one signature to verify: a hex public key, a hex signature and
+// the message it signs.
+type VerifyTuple struct {
+	PubKey    string `json:"pubkey"`
+	Signature string `json:"signature"`
+	Message   string `json:"message"`
+}
+
+// decodedBatch is a batch of (signature, public key, message) tuples decoded
+// once so that subsets can be re-verified cheaply.
+type decodedBatch struct {
+	sigs  [][]byte
+	pks   []common.PublicKey
+	roots [][32]byte
+}
+
+func decodeBatch(sigHexes, pubKeyHexes, msgs []string) (*decodedBatch, error) {
+	if len(sigHexes) != len(pubKeyHexes) || len(sigHexes) != len(msgs) {
+		return nil, fmt.Errorf("%w: %d signatures, %d public keys, %d messages",
+			ErrLengthMismatch, len(sigHexes), len(pubKeyHexes), len(msgs))
+	}
+	if len(sigHexes) == 0 {
+		return nil, ErrNoSignatures
+	}
+	b := &decodedBatch{
+		sigs:  make([][]byte, len(sigHexes)),
+		pks:   make([]common.PublicKey, len(pubKeyHexes)),
+		roots: make([][32]byte, len(msgs)),
+	}
+	for i, h := range sigHexes {
+		sig, err := decodeHex(h)
+		if err == nil {
+			err = checkSignatureLength(sig, SignatureLength)
+		}
+		if err != nil {
+			return nil, batchElementError(i, err)
+		}
+		if b.pks[i], err = publicKeyFromHex(pubKeyHexes[i]); err != nil {
+			return nil, batchElementError(i, err)
+		}
+		b.sigs[i] = sig
+		b.roots[i] = signingRoot([]byte(msgs[i]))
+	}
+	return b, nil
+}
+
+// batchElementError prefixes err with the index of the batch element that
+// caused it, e.g. "element 4: invalid public key: public key is 47 bytes,
+// want 48".
+func batchElementError(i int, err error) error {
+	return fmt.Errorf("element %d: %w", i, err)
+}
+
+// verify batch-verifies the tuples in [lo, hi) with random coefficients.
+func (b *decodedBatch) verify(lo, hi int) (bool, error) {
+	return bls.VerifyMultipleSignatures(b.sigs[lo:hi], b.roots[lo:hi], b.pks[lo:hi])
+}
+
+// firstFailure bisects [lo, hi), which is known to fail, down to the lowest
+// failing index.
+func (b *decodedBatch) firstFailure(lo, hi int) (int, error) {
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		ok, err := b.verify(lo, mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// firstInvalidPoint returns the index of the first signature that is the
+// right size but not a G2 subgroup point, or -1 if every one is.
+func (b *decodedBatch) firstInvalidPoint() int {
+	for i, sig := range b.sigs {
+		if _, err := SignatureFromBytes(sig); err != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// VerifyBatchFirstFailure batch-verifies aligned signatures, public keys and
+// messages, returning -1 if all are valid or else the index of the first
+// invalid tuple. Failing batches are bisected rather than checked one by one.
+// A signature of the right size that is not a valid point is an invalid
+// tuple, not an error; one of the wrong size is an error.
+func VerifyBatchFirstFailure(sigHexes, pubKeyHexes, msgs []string) (int, error) {
+	b, err := decodeBatch(sigHexes, pubKeyHexes, msgs)
+	if err != nil {
+		return 0, err
+	}
+	// Only the tuples before the first undecodable signature can fail first.
+	end := len(b.sigs)
+	if i := b.firstInvalidPoint(); i >= 0 {
+		end = i
+	}
+	if end == 0 {
+		return 0, nil
+	}
+	ok, err := b.verify(0, end)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return b.firstFailure(0, end)
+	}
+	if end < len(b.sigs) {
+		return end, nil
+	}
+	return -1, nil
+}
+
+// AggregateValidOnly verifies each entry on its own and aggregates only those
+// that pass, so one bad signature from an untrusted peer can't poison the
+// aggregate. Malformed entries count as invalid. included lists the indices
+// that went into the aggregate.
+func AggregateValidOnly(entries []VerifyTuple) (aggSigHex string, included []int, err error) {
+	var sigs []string
+	for i, e := range entries {
+		if ok, err := VerifySignature(e.PubKey, e.Signature, e.Message); err != nil || !ok {
+			continue
+		}
+		sigs = append(sigs, e.Signature)
+		included = append(included, i)
+	}
+	if len(sigs) == 0 {
+		return "", nil, ErrNoValidSignatures
+	}
+	aggSigHex, err = AggregateSignatures(sigs)
+	if err != nil {
+		return "", nil, err
+	}
+	return aggSigHex, included, nil
+}
+
+// BatchVerifier accumulates tuples for a single batch verification. Its
+// pending set round-trips through JSON so a long job can resume after a restart.
+type BatchVerifier struct {
+	mu      sync.Mutex
+	pending []VerifyTuple
+}
+
+// NewBatchVerifier returns an empty BatchVerifier.
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// AddPending queues a tuple for the next VerifyAll.
+func (b *BatchVerifier) AddPending(sigHex, pubKeyHex, msg string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = append(b.pending, VerifyTuple{PubKey: pubKeyHex, Signature: sigHex, Message: msg})
+}
+
+// Len returns the number of pending tuples.
+func (b *BatchVerifier) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// VerifyAll batch-verifies every pending tuple. An empty set is valid.
+func (b *BatchVerifier) VerifyAll() (bool, error) {
+	b.mu.Lock()
+	entries := append([]VerifyTuple(nil), b.pending...)
+	b.mu.Unlock()
+	if len(entries) == 0 {
+		return true, nil
+	}
+	sigs, pks, msgs := splitTuples(entries)
+	d, err := decodeBatch(sigs, pks, msgs)
+	if err != nil {
+		return false, err
+	}
+	return d.verify(0, len(entries))
+}
+
+// MarshalJSON encodes the pending set.
+func (b *BatchVerifier) MarshalJSON() ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return json.Marshal(b.pending)
+}
+
+// UnmarshalJSON replaces the pending set with one saved by MarshalJSON.
+func (b *BatchVerifier) UnmarshalJSON(data []byte) error {
+	var pending []VerifyTuple
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = pending
+	return nil
+}
+
+func splitTuples(entries []VerifyTuple) (sigs, pks, msgs []string) {
+	sigs = make([]string, len(entries))
+	pks = make([]string, len(entries))
+	msgs = make([]string, len(entries))
+	for i, e := range entries {
+		sigs[i], pks[i], msgs[i] = e.Signature, e.PubKey, e.Message
+	}
+	return sigs, pks, msgs
+}
+
+// randBits is the size of the random coefficients used when combining a
+// same-message batch, matching the batch verifier in the backend.
+const randBits = 64
+
+// VerifyMultipleSignatures batch-verifies aligned signatures, public keys and
+// messages. If every message is identical it avoids one Miller loop per
+// signature: it checks the single pairing e(Σ rᵢσᵢ, g) = e(H(m), Σ rᵢpkᵢ)
+// with random rᵢ, which, like the general path, rejects signatures that are
+// only valid in combination. For 64 signatures that is about 2.5x faster
+// (BenchmarkVerifyMultipleSignaturesSameMessage).
+func VerifyMultipleSignatures(sigHexes, pubKeyHexes, msgs []string) (bool, error) {
+	if len(msgs) > 1 && allEqual(msgs) && len(sigHexes) == len(msgs) && len(pubKeyHexes) == len(msgs) {
+		return verifySameMessageBatch(sigHexes, pubKeyHexes, msgs[0])
+	}
+	b, err := decodeBatch(sigHexes, pubKeyHexes, msgs)
+	if err != nil {
+		return false, err
+	}
+	return b.verify(0, len(b.sigs))
+}
+
+func allEqual(msgs []string) bool {
+	for _, m := range msgs[1:] {
+		if m != msgs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func verifySameMessageBatch(sigHexes, pubKeyHexes []string, msg string) (bool, error) {
+	n := len(sigHexes)
+	sigs := make([]*blst.P2Affine, n)
+	pks := make([]*blst.P1Affine, n)
+	for i := range sigHexes {
+		b, err := decodeHex(sigHexes[i])
+		if err == nil {
+			err = checkSignatureLength(b, SignatureLength)
+		}
+		if err == nil {
+			sigs[i], err = decodeSignaturePoint(b)
+		}
+		if err != nil {
+			return false, batchElementError(i, err)
+		}
+		if pks[i], err = blstPublicKeyFromHex(pubKeyHexes[i]); err != nil {
+			return false, batchElementError(i, err)
+		}
+	}
+
+	scalars := make([]byte, n*randBits/8)
+	if _, err := rand.Read(scalars); err != nil {
+		return false, err
+	}
+	for i := 0; i < len(scalars); i += randBits / 8 {
+		scalars[i] |= 1 // never zero
+	}
+	aggSig := blst.P2AffinesMult(sigs, scalars, randBits).ToAffine()
+	aggPk := blst.P1AffinesMult(pks, scalars, randBits).ToAffine()
+	root := signingRoot([]byte(msg))
+	return aggSig.Verify(false, aggPk, false, root[:], schemeDSTs[SchemePOP]), nil
+}
+
+var ErrInvalidThreshold = errors.New("threshold must be positive")
+
+// validIndices appends the indices in [lo, hi) whose tuples verify. A range
+// that batch-verifies is accepted whole; a failing one is split in half.
+func (b *decodedBatch) validIndices(lo, hi int, out []int) ([]int, error) {
+	ok, err := b.verify(lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		for i := lo; i < hi; i++ {
+			out = append(out, i)
+		}
+		return out, nil
+	}
+	if hi-lo == 1 {
+		return out, nil
+	}
+	mid := lo + (hi-lo)/2
+	if out, err = b.validIndices(lo, mid, out); err != nil {
+		return nil, err
+	}
+	return b.validIndices(mid, hi, out)
+}
+
+// VerifyThresholdCount reports whether at least k of entries are valid and
+// which ones are. Malformed entries count as invalid. The whole set is batch
+// verified first; only failing halves are re-checked.
+func VerifyThresholdCount(entries []VerifyTuple, k int) (bool, []int, error) {
+	if k <= 0 {
+		return false, nil, ErrInvalidThreshold
+	}
+	b := &decodedBatch{}
+	var positions []int
+	for i, e := range entries {
+		sig, err := decodeHex(e.Signature)
+		if err != nil {
+			continue
+		}
+		if _, err := SignatureFromBytes(sig); err != nil {
+			continue
+		}
+		pk, err := publicKeyFromHex(e.PubKey)
+		if err != nil {
+			continue
+		}
+		b.sigs = append(b.sigs, sig)
+		b.pks = append(b.pks, pk)
+		b.roots = append(b.roots, signingRoot([]byte(e.Message)))
+		positions = append(positions, i)
+	}
+	if len(positions) == 0 {
+		return false, nil, nil
+	}
+	local, err := b.validIndices(0, len(positions), nil)
+	if err != nil {
+		return false, nil, err
+	}
+	valid := make([]int, len(local))
+	for i, j := range local {
+		valid[i] = positions[j]
+	}
+	return len(valid) >= k, valid, nil
+}
+
+// PartitionBatch splits entries into at most shards contiguous, near-equal
+// slices, in order, so the same batch always maps to the same shards. It never
+// returns an empty shard; shards below 1 is treated as 1.
+func PartitionBatch(entries []VerifyTuple, shards int) [][]VerifyTuple {
+	shards = min(max(shards, 1), len(entries))
+	parts := make([][]VerifyTuple, shards)
+	for i := range parts {
+		lo, hi := i*len(entries)/shards, (i+1)*len(entries)/shards
+		parts[i] = entries[lo:hi]
+	}
+	return parts
+}
+
+// CombineShardResults folds per-shard verdicts from PartitionBatch into one:
+// the batch is valid only if there is at least one shard and every shard
+// verified.
+func CombineShardResults(results []bool) bool {
+	if len(results) == 0 {
+		return false
+	}
+	for _, ok := range results {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
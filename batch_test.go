@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// testTuples returns n valid tuples, each from its own key over its own message.
+func testTuples(t *testing.T, n int) []VerifyTuple {
+	t.Helper()
+	entries := make([]VerifyTuple, n)
+	for i := range entries {
+		sk, pk := testKeyPair(t)
+		msg := "message " + string(rune('a'+i))
+		entries[i] = VerifyTuple{PubKey: pk, Signature: mustSign(t, sk, msg), Message: msg}
+	}
+	return entries
+}
+
+func TestVerifyBatchFirstFailure(t *testing.T) {
+	entries := testTuples(t, 5)
+	sigs, pks, msgs := splitTuples(entries)
+	if got, err := VerifyBatchFirstFailure(sigs, pks, msgs); err != nil || got != -1 {
+		t.Fatalf("all valid: got %d, %v; want -1", got, err)
+	}
+	msgs[2] = "tampered"
+	if got, err := VerifyBatchFirstFailure(sigs, pks, msgs); err != nil || got != 2 {
+		t.Fatalf("failure at 2: got %d, %v; want 2", got, err)
+	}
+}
+
+func TestVerifyBatchFirstFailureInvalidPoint(t *testing.T) {
+	entries := testTuples(t, 5)
+	sigs, pks, msgs := splitTuples(entries)
+	for _, bad := range [][]byte{
+		offSubgroupSignature(t),
+		bytes.Repeat([]byte{0x11}, SignatureLength), // no compression flag
+	} {
+		sigs[2] = EncodingEthereum.Encode(bad)
+		if got, err := VerifyBatchFirstFailure(sigs, pks, msgs); err != nil || got != 2 {
+			t.Fatalf("invalid point at 2: got %d, %v; want 2", got, err)
+		}
+		msgs[1] = "tampered"
+		if got, err := VerifyBatchFirstFailure(sigs, pks, msgs); err != nil || got != 1 {
+			t.Fatalf("failure at 1 before invalid point: got %d, %v; want 1", got, err)
+		}
+		msgs[1] = entries[1].Message
+	}
+	sigs[0] = sigs[2]
+	if got, err := VerifyBatchFirstFailure(sigs, pks, msgs); err != nil || got != 0 {
+		t.Fatalf("invalid point at 0: got %d, %v; want 0", got, err)
+	}
+}
+
+func TestAggregateValidOnlyExcludesInvalid(t *testing.T) {
+	entries := testTuples(t, 4)
+	entries[1].Message = "not what was signed"
+	agg, included, err := AggregateValidOnly(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(included, []int{0, 2, 3}) {
+		t.Fatalf("included = %v, want [0 2 3]", included)
+	}
+	var pks, msgs []string
+	for _, i := range included {
+		pks = append(pks, entries[i].PubKey)
+		msgs = append(msgs, entries[i].Message)
+	}
+	if ok, err := AggregateVerify(agg, pks, msgs, ""); err != nil || !ok {
+		t.Fatalf("aggregate of valid entries: %v, %v; want true", ok, err)
+	}
+}
+
+func TestBatchVerifierResume(t *testing.T) {
+	entries := testTuples(t, 6)
+	bad := append([]VerifyTuple(nil), entries...)
+	bad[4].Message = "tampered"
+
+	for _, set := range [][]VerifyTuple{entries, bad} {
+		straight := NewBatchVerifier()
+		first := NewBatchVerifier()
+		for i, e := range set {
+			straight.AddPending(e.Signature, e.PubKey, e.Message)
+			if i < 3 {
+				first.AddPending(e.Signature, e.PubKey, e.Message)
+			}
+		}
+		want, err := straight.VerifyAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		saved, err := json.Marshal(first)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resumed := NewBatchVerifier()
+		if err := json.Unmarshal(saved, resumed); err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range set[3:] {
+			resumed.AddPending(e.Signature, e.PubKey, e.Message)
+		}
+		if resumed.Len() != len(set) {
+			t.Fatalf("resumed %d tuples, want %d", resumed.Len(), len(set))
+		}
+		got, err := resumed.VerifyAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("resumed VerifyAll = %v, straight-through = %v", got, want)
+		}
+	}
+}
+
+// generalBatchVerify runs the general multi-message path even when every
+// message is the same.
+func generalBatchVerify(t testing.TB, sigs, pks, msgs []string) bool {
+	t.Helper()
+	b, err := decodeBatch(sigs, pks, msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := b.verify(0, len(b.sigs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ok
+}
+
+// shiftSignature returns sigHex plus (or minus) the G2 generator.
+func shiftSignature(t *testing.T, sigHex string, minus bool) string {
+	t.Helper()
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p blst.P2
+	p.FromAffine(new(blst.P2Affine).Uncompress(b))
+	if minus {
+		p.SubAssign(blst.P2Generator())
+	} else {
+		p.AddAssign(blst.P2Generator())
+	}
+	return EncodingEthereum.Encode(p.ToAffine().Compress())
+}
+
+func TestVerifyMultipleSignaturesSameMessage(t *testing.T) {
+	_, pks, sigs := testSigners(t, 4, "slot 9")
+	msgs := []string{"slot 9", "slot 9", "slot 9", "slot 9"}
+
+	wrongKey := append([]string(nil), pks...)
+	wrongKey[1], wrongKey[2] = pks[2], pks[1]
+	// Shifting two signatures by opposite amounts keeps their sum, so only a
+	// randomised check notices.
+	cancelling := append([]string(nil), sigs...)
+	cancelling[0] = shiftSignature(t, sigs[0], false)
+	cancelling[3] = shiftSignature(t, sigs[3], true)
+
+	tests := []struct {
+		name string
+		sigs []string
+		pks  []string
+		want bool
+	}{
+		{"valid", sigs, pks, true},
+		{"swapped keys", sigs, wrongKey, false},
+		{"cancelling errors", cancelling, pks, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyMultipleSignatures(tt.sigs, tt.pks, msgs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if general := generalBatchVerify(t, tt.sigs, tt.pks, msgs); got != general || got != tt.want {
+				t.Fatalf("same-message path = %v, general path = %v, want %v", got, general, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyMultipleSignaturesSameMessage(b *testing.B) {
+	const n = 64
+	sigs := make([]string, n)
+	pks := make([]string, n)
+	msgs := make([]string, n)
+	for i := range sigs {
+		sk, pk, err := GenerateKeyPair()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if sigs[i], err = GenerateSignature(sk, []byte("slot 9")); err != nil {
+			b.Fatal(err)
+		}
+		pks[i], msgs[i] = pk, "slot 9"
+	}
+	b.Run("same-message", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if ok, err := VerifyMultipleSignatures(sigs, pks, msgs); err != nil || !ok {
+				b.Fatal(ok, err)
+			}
+		}
+	})
+	b.Run("general", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if !generalBatchVerify(b, sigs, pks, msgs) {
+				b.Fatal("batch failed")
+			}
+		}
+	})
+}
+
+func TestVerifyThresholdCount(t *testing.T) {
+	entries := testTuples(t, 5)
+	entries[0].Message = "tampered"
+	entries[3].Signature = "0x1234"
+	ok, valid, err := VerifyThresholdCount(entries, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || !reflect.DeepEqual(valid, []int{1, 2, 4}) {
+		t.Fatalf("k=3: %v, %v; want true, [1 2 4]", ok, valid)
+	}
+	if ok, _, err := VerifyThresholdCount(entries, 4); err != nil || ok {
+		t.Fatalf("k=4: %v, %v; want false", ok, err)
+	}
+}
+
+func TestPartitionAndCombineMatchSingleMachine(t *testing.T) {
+	entries := testTuples(t, 7)
+	bad := append([]VerifyTuple(nil), entries...)
+	bad[5].Message = "tampered"
+
+	for _, set := range [][]VerifyTuple{entries, bad} {
+		sigs, pks, msgs := splitTuples(set)
+		single, err := VerifyMultipleSignatures(sigs, pks, msgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts := PartitionBatch(set, 3)
+		if len(parts) != 3 || len(parts[0])+len(parts[1])+len(parts[2]) != len(set) {
+			t.Fatalf("PartitionBatch into 3 gave sizes %d, %d, %d", len(parts[0]), len(parts[1]), len(parts[2]))
+		}
+		var results []bool
+		for _, p := range parts {
+			bv := NewBatchVerifier()
+			for _, e := range p {
+				bv.AddPending(e.Signature, e.PubKey, e.Message)
+			}
+			ok, err := bv.VerifyAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			results = append(results, ok)
+		}
+		if got := CombineShardResults(results); got != single {
+			t.Fatalf("sharded verdict %v, single-machine %v", got, single)
+		}
+	}
+	if !reflect.DeepEqual(PartitionBatch(entries, 3), PartitionBatch(entries, 3)) {
+		t.Fatal("PartitionBatch is not deterministic")
+	}
+}
+
+func TestBatchErrorsNameElement(t *testing.T) {
+	const bad = 4
+	// Drop the last byte of element bad's public key or signature.
+	truncate := func(h string) string { return h[:len(h)-2] }
+	distinct := testTuples(t, 6)
+	_, samePks, sameSigs := testSigners(t, 6, "same")
+	sameMsgs := make([]string, len(samePks))
+	for i := range sameMsgs {
+		sameMsgs[i] = "same"
+	}
+
+	cases := []struct {
+		name   string
+		verify func(sigs, pks, msgs []string) error
+	}{
+		{"VerifyMultipleSignatures", func(sigs, pks, msgs []string) error {
+			_, err := VerifyMultipleSignatures(sigs, pks, msgs)
+			return err
+		}},
+		{"VerifyBatchFirstFailure", func(sigs, pks, msgs []string) error {
+			_, err := VerifyBatchFirstFailure(sigs, pks, msgs)
+			return err
+		}},
+		{"VerifyParallel", func(sigs, pks, msgs []string) error {
+			entries := make([]VerifyTuple, len(sigs))
+			for i := range entries {
+				entries[i] = VerifyTuple{PubKey: pks[i], Signature: sigs[i], Message: msgs[i]}
+			}
+			_, err := VerifyParallel(entries)
+			return err
+		}},
+	}
+	for _, c := range cases {
+		for _, same := range []bool{false, true} {
+			sigs, pks, msgs := splitTuples(distinct)
+			if same {
+				sigs, pks, msgs = append([]string(nil), sameSigs...), append([]string(nil), samePks...), sameMsgs
+			}
+			for _, elem := range []struct {
+				kind string
+				hexs []string
+				want string
+			}{
+				{"public key", pks, "public key is 47 bytes, want 48"},
+				{"signature", sigs, "signature is 95 bytes, want 96"},
+			} {
+				orig := elem.hexs[bad]
+				elem.hexs[bad] = truncate(orig)
+				err := c.verify(sigs, pks, msgs)
+				elem.hexs[bad] = orig
+				if err == nil {
+					t.Fatalf("%s (same message %v): short %s accepted", c.name, same, elem.kind)
+				}
+				if msg := err.Error(); !strings.Contains(msg, "element 4: ") || !strings.Contains(msg, elem.want) {
+					t.Errorf("%s (same message %v): short %s: error %q, want element 4 and %q", c.name, same, elem.kind, msg, elem.want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var ErrInvalidBlindingFactor = errors.New("blinding factor must be a 32-byte scalar in range 0 < r < q")
+
+// Blind signatures (Boldyreva): the requester sends r·H(m) instead of m, the
+// signer returns sk·r·H(m), and multiplying by 1/r leaves sk·H(m), an
+// ordinary signature over m that the signer never saw. Because the signer
+// signs whatever point it is given, a key used with BlindSign signs arbitrary
+// messages on request and should not be used for anything else.
+
+// Blind hashes msg to G2 as VerifySignature does and multiplies it by a fresh
+// random blinding factor. The requester keeps the factor to pass to Unblind.
+func Blind(msg []byte) (blindedMsgHex string, blindingFactor []byte, err error) {
+	ikm, err := randomBytes(32)
+	if err != nil {
+		return "", nil, err
+	}
+	defer zeroize(ikm)
+	r := blst.KeyGen(ikm)
+	defer r.Zeroize()
+	dst, err := SchemePOP.DST()
+	if err != nil {
+		return "", nil, err
+	}
+	root := signingRoot(msg)
+	blinded := blst.HashToG2(root[:], dst).Mult(r).ToAffine()
+	return EncodingEthereum.Encode(blinded.Compress()), r.Serialize(), nil
+}
+
+// BlindSign multiplies a blinded message point from Blind by the secret key.
+func BlindSign(skHex string, blindedMsgHex string) (string, error) {
+	b, err := decodeHex(blindedMsgHex)
+	if err != nil {
+		return "", err
+	}
+	// A blinded message is a G2 point, so it decodes like a signature.
+	point, err := decodeSignaturePoint(b)
+	if err != nil {
+		return "", err
+	}
+	sk, err := blstSecretKeyFromHex(skHex)
+	if err != nil {
+		return "", err
+	}
+	defer sk.Zeroize()
+	var p blst.P2
+	p.FromAffine(point)
+	return EncodingEthereum.Encode(p.Mult(sk).ToAffine().Compress()), nil
+}
+
+// Unblind removes the blinding factor from a BlindSign result, giving a
+// signature over the original message that VerifySignature accepts.
+func Unblind(blindSigHex string, blindingFactor []byte) (string, error) {
+	if checkSecretKeyRange(blindingFactor) != nil {
+		return "", ErrInvalidBlindingFactor
+	}
+	r := new(blst.Scalar).Deserialize(blindingFactor)
+	if r == nil {
+		return "", ErrInvalidBlindingFactor
+	}
+	defer r.Zeroize()
+	rInv := r.Inverse()
+	defer rInv.Zeroize()
+
+	b, err := decodeHex(blindSigHex)
+	if err != nil {
+		return "", err
+	}
+	point, err := decodeSignaturePoint(b)
+	if err != nil {
+		return "", err
+	}
+	var p blst.P2
+	p.FromAffine(point)
+	return EncodingEthereum.Encode(p.Mult(rInv).ToAffine().Compress()), nil
+}
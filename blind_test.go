@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBlindSignature(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("ballot 42")
+
+	blinded, factor, err := Blind(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blindSig, err := BlindSign(skHex, blinded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, blindSig, string(msg)); err != nil || ok {
+		t.Fatalf("blinded signature verified before unblinding: ok=%v err=%v", ok, err)
+	}
+	sig, err := Unblind(blindSig, factor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, sig, string(msg)); err != nil || !ok {
+		t.Fatalf("unblinded signature: ok=%v err=%v", ok, err)
+	}
+	// BLS is deterministic, so the result is the ordinary signature.
+	if want := mustSign(t, skHex, string(msg)); sig != want {
+		t.Fatalf("unblinded signature %s, want %s", sig, want)
+	}
+
+	if _, err := Unblind(blindSig, make([]byte, 32)); !errors.Is(err, ErrInvalidBlindingFactor) {
+		t.Fatalf("zero factor: err = %v, want ErrInvalidBlindingFactor", err)
+	}
+}
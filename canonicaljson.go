@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// canonicalJSON encodes v with object keys sorted and no insignificant
+// whitespace. v is marshaled, decoded into generic values and marshaled again,
+// so struct field order and the key order of raw JSON don't matter. Numbers
+// keep their original text and HTML characters are not escaped. It is not a
+// full RFC 8785 encoder: numbers are not normalised, so 1 and 1.0 differ.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var generic interface{}
+	if err := d.Decode(&generic); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// jsonDomain separates JSON signatures from signatures over the same bytes
+// as an ordinary message.
+var jsonDomain = []byte("bls-sig canonical json")
+
+func jsonRoot(v interface{}) ([32]byte, error) {
+	b, err := canonicalJSON(v)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if err := checkMessageSize(len(b)); err != nil {
+		return [32]byte{}, err
+	}
+	return BuildSigningInput(jsonDomain, b), nil
+}
+
+// SignJSON signs the canonical JSON encoding of v.
+func SignJSON(skHex string, v interface{}) (string, error) {
+	root, err := jsonRoot(v)
+	if err != nil {
+		return "", err
+	}
+	return signRoot(skHex, root)
+}
+
+// VerifyJSON checks a SignJSON signature over v.
+func VerifyJSON(pubKeyHex, sigHex string, v interface{}) (bool, error) {
+	root, err := jsonRoot(v)
+	if err != nil {
+		return false, err
+	}
+	return verifyRoot(pubKeyHex, sigHex, root)
+}
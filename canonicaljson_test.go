@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSignJSONKeyOrder(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	a := json.RawMessage(`{"amount": 5, "to": "bob", "meta": {"x": 1, "y": [2, 3]}}`)
+	b := json.RawMessage(`{"meta":{"y":[2,3],"x":1},"to":"bob","amount":5}`)
+
+	sig, err := SignJSON(skHex, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyJSON(pubKeyHex, sig, b); err != nil || !ok {
+		t.Fatalf("reordered keys: ok=%v err=%v", ok, err)
+	}
+	if ok, err := VerifyJSON(pubKeyHex, sig, json.RawMessage(`{"amount":6,"to":"bob","meta":{"x":1,"y":[2,3]}}`)); err != nil || ok {
+		t.Fatalf("changed value: ok=%v err=%v", ok, err)
+	}
+
+	// JSON signatures are domain-separated from ordinary messages.
+	canon, err := canonicalJSON(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"amount":5,"meta":{"x":1,"y":[2,3]},"to":"bob"}`; string(canon) != want {
+		t.Fatalf("canonicalJSON = %s, want %s", canon, want)
+	}
+	if ok, err := VerifySignature(pubKeyHex, sig, string(canon)); err != nil || ok {
+		t.Fatalf("verified as a plain message: ok=%v err=%v", ok, err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrIncompatible = errors.New("no compatible capabilities")
+
+// CapabilitySet describes what a node can do, exchanged when two nodes
+// connect. Scheme and encoding names match those in Manifest. A
+// MaxMessageBytes of zero means unlimited.
+type CapabilitySet struct {
+	Schemes         []string `json:"schemes"`
+	Encodings       []string `json:"encodings"`
+	MaxMessageBytes int      `json:"max_message_bytes"`
+	Aggregation     bool     `json:"aggregation"`
+}
+
+// Capabilities returns this library's capabilities, in order of preference,
+// with the limit currently set by SetMaxMessageBytes.
+func Capabilities() CapabilitySet {
+	return CapabilitySet{
+		Schemes:         []string{"pop", "basic"},
+		Encodings:       []string{"0x-hex", "nist-hex"},
+		MaxMessageBytes: MaxMessageBytes(),
+		Aggregation:     true,
+	}
+}
+
+// NegotiateCapabilities returns what local and remote both support: the
+// common schemes and encodings in local's order of preference, the smaller
+// message limit, and aggregation only if both offer it. It returns
+// ErrIncompatible if they share no scheme or no encoding.
+func NegotiateCapabilities(local, remote CapabilitySet) (agreed CapabilitySet, err error) {
+	agreed.Schemes = intersectOrdered(local.Schemes, remote.Schemes)
+	if len(agreed.Schemes) == 0 {
+		return CapabilitySet{}, fmt.Errorf("%w: no common scheme", ErrIncompatible)
+	}
+	agreed.Encodings = intersectOrdered(local.Encodings, remote.Encodings)
+	if len(agreed.Encodings) == 0 {
+		return CapabilitySet{}, fmt.Errorf("%w: no common encoding", ErrIncompatible)
+	}
+	agreed.MaxMessageBytes = local.MaxMessageBytes
+	if remote.MaxMessageBytes > 0 && (agreed.MaxMessageBytes == 0 || remote.MaxMessageBytes < agreed.MaxMessageBytes) {
+		agreed.MaxMessageBytes = remote.MaxMessageBytes
+	}
+	agreed.Aggregation = local.Aggregation && remote.Aggregation
+	return agreed, nil
+}
+
+// intersectOrdered returns the distinct elements of a that are also in b, in
+// a's order.
+func intersectOrdered(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if inB[s] {
+			out = append(out, s)
+			inB[s] = false
+		}
+	}
+	return out
+}
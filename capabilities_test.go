@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNegotiateCapabilities(t *testing.T) {
+	local := CapabilitySet{
+		Schemes:         []string{"pop", "basic"},
+		Encodings:       []string{"0x-hex", "nist-hex"},
+		MaxMessageBytes: 0,
+		Aggregation:     true,
+	}
+	remote := CapabilitySet{
+		Schemes:         []string{"basic", "pop", "aug"},
+		Encodings:       []string{"nist-hex", "base64"},
+		MaxMessageBytes: 1 << 20,
+		Aggregation:     false,
+	}
+	agreed, err := NegotiateCapabilities(local, remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CapabilitySet{
+		Schemes:         []string{"pop", "basic"},
+		Encodings:       []string{"nist-hex"},
+		MaxMessageBytes: 1 << 20,
+		Aggregation:     false,
+	}
+	if !reflect.DeepEqual(agreed, want) {
+		t.Fatalf("agreed %+v, want %+v", agreed, want)
+	}
+
+	disjointSchemes := CapabilitySet{Schemes: []string{"aug"}, Encodings: []string{"0x-hex"}}
+	if _, err := NegotiateCapabilities(local, disjointSchemes); !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("no common scheme: err = %v, want ErrIncompatible", err)
+	}
+	disjointEncodings := CapabilitySet{Schemes: []string{"pop"}, Encodings: []string{"base64"}}
+	if _, err := NegotiateCapabilities(local, disjointEncodings); !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("no common encoding: err = %v, want ErrIncompatible", err)
+	}
+}
+
+func TestCapabilitiesMatchManifest(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	s, err := NewLocalSigner(skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, caps := s.Manifest(), Capabilities()
+	if caps.Schemes[0] != m.Scheme || caps.Encodings[0] != m.Encoding {
+		t.Fatalf("preferred capabilities %s/%s, signer manifest %s/%s", caps.Schemes[0], caps.Encodings[0], m.Scheme, m.Encoding)
+	}
+}
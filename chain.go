@@ -0,0 +1,57 @@
+package main
+
+import "fmt"
+
+// chainDomain separates chain links from signatures over ordinary messages.
+var chainDomain = []byte("bls-sig signature chain")
+
+// ChainEntry is one link of a signature chain: Signature covers Payload and
+// the previous entry's signature.
+type ChainEntry struct {
+	PubKey    string `json:"pubkey"`
+	Signature string `json:"signature"`
+	Payload   string `json:"payload"`
+}
+
+func chainRoot(prevSigHex, payload string) ([32]byte, error) {
+	var prev []byte
+	if prevSigHex != "" {
+		var err error
+		if prev, err = decodeHex(prevSigHex); err != nil {
+			return [32]byte{}, fmt.Errorf("previous signature: %w", err)
+		}
+	}
+	return BuildSigningInput(chainDomain, prev, []byte(payload)), nil
+}
+
+// SignChainEntry signs payload as the link after prevSigHex. Pass an empty
+// prevSigHex for the first entry.
+func SignChainEntry(skHex, prevSigHex, payload string) (string, error) {
+	root, err := chainRoot(prevSigHex, payload)
+	if err != nil {
+		return "", err
+	}
+	return signRoot(skHex, root)
+}
+
+// VerifySignatureChain checks every link of a chain built with
+// SignChainEntry. It returns true and -1 if the chain is intact, or false and
+// the index of the first broken link. A malformed entry counts as broken; an
+// empty chain returns ErrNoSignatures.
+func VerifySignatureChain(entries []ChainEntry) (bool, int, error) {
+	if len(entries) == 0 {
+		return false, -1, ErrNoSignatures
+	}
+	prev := ""
+	for i, e := range entries {
+		root, err := chainRoot(prev, e.Payload)
+		if err != nil {
+			return false, i, nil
+		}
+		if ok, err := verifyRoot(e.PubKey, e.Signature, root); err != nil || !ok {
+			return false, i, nil
+		}
+		prev = e.Signature
+	}
+	return true, -1, nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestVerifySignatureChain(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	var entries []ChainEntry
+	prev := ""
+	for _, p := range []string{"genesis", "deposit", "transfer", "withdraw"} {
+		sig, err := SignChainEntry(skHex, prev, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, ChainEntry{PubKey: pubKeyHex, Signature: sig, Payload: p})
+		prev = sig
+	}
+	if ok, broken, err := VerifySignatureChain(entries); err != nil || !ok || broken != -1 {
+		t.Fatalf("intact chain: %v, %d, %v; want true, -1", ok, broken, err)
+	}
+
+	tampered := append([]ChainEntry(nil), entries...)
+	tampered[2].Payload = "transfer everything"
+	if ok, broken, err := VerifySignatureChain(tampered); err != nil || ok || broken != 2 {
+		t.Fatalf("tampered middle link: %v, %d, %v; want false, 2", ok, broken, err)
+	}
+}
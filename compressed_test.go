@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+func TestSignCompressed(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := bytes.Repeat([]byte("block body "), 100)
+	sig, compressed, err := SignCompressed(skHex, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyCompressed(pubKeyHex, sig, compressed); err != nil || !ok {
+		t.Fatalf("VerifyCompressed: ok=%v err=%v", ok, err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, msg) {
+		t.Fatal("compressed payload does not decompress to msg")
+	}
+
+	// The signature covers the compressed bytes, not msg.
+	if ok, err := VerifySignature(pubKeyHex, sig, string(msg)); err != nil || ok {
+		t.Fatalf("verified over the decompressed bytes: ok=%v err=%v", ok, err)
+	}
+	tampered := append([]byte(nil), compressed...)
+	tampered[len(tampered)-1] ^= 1
+	if ok, err := VerifyCompressed(pubKeyHex, sig, tampered); err != nil || ok {
+		t.Fatalf("tampered payload: ok=%v err=%v", ok, err)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// decodeInput reads value in the given text or file format.
+func decodeInput(value, from string) ([]byte, error) {
+	switch from {
+	case "hex":
+		return decodeHex(value)
+	case "base64":
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64: %w", err)
+		}
+		return b, nil
+	case "raw":
+		return os.ReadFile(value)
+	}
+	return nil, fmt.Errorf("%w: --from %q", ErrUnknownFormat, from)
+}
+
+// encodeOutput writes b to w in the given format; raw writes the bytes as-is.
+func encodeOutput(w io.Writer, b []byte, to string) error {
+	var err error
+	switch to {
+	case "hex":
+		_, err = fmt.Fprintln(w, EncodingEthereum.Encode(b))
+	case "base64":
+		_, err = fmt.Fprintln(w, base64.StdEncoding.EncodeToString(b))
+	case "raw":
+		_, err = w.Write(b)
+	default:
+		err = fmt.Errorf("%w: --to %q", ErrUnknownFormat, to)
+	}
+	return err
+}
+
+// convertPoint validates b as a public key (G1) or signature (G2) in either
+// point form and re-encodes it compressed or uncompressed.
+func convertPoint(b []byte, typ string, uncompressed bool) ([]byte, error) {
+	switch typ {
+	case "signature":
+		p, err := decodeSignaturePoint(b)
+		if err != nil {
+			return nil, err
+		}
+		if uncompressed {
+			return p.Serialize(), nil
+		}
+		return p.Compress(), nil
+	case "pubkey":
+		var p *blst.P1Affine
+		switch len(b) {
+		case blst.BLST_P1_COMPRESS_BYTES:
+			p = new(blst.P1Affine).Uncompress(b)
+		case blst.BLST_P1_SERIALIZE_BYTES:
+			p = new(blst.P1Affine).Deserialize(b)
+		}
+		if p == nil || !p.KeyValidate() {
+			return nil, ErrInvalidPublicKey
+		}
+		if uncompressed {
+			return p.Serialize(), nil
+		}
+		return p.Compress(), nil
+	}
+	return nil, fmt.Errorf("%w: --type %q", ErrUnknownFormat, typ)
+}
+
+// runConvert implements the convert command:
+//
+//	convert --from hex|base64|raw --to hex|base64|raw --type signature|pubkey [--uncompressed] <value>
+//
+// For --from raw, value is a path to a file holding the bytes.
+func runConvert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	from := fs.String("from", "hex", "input format: hex, base64 or raw (value is a file path)")
+	to := fs.String("to", "hex", "output format: hex, base64 or raw (bytes to stdout)")
+	typ := fs.String("type", "signature", "element type: signature or pubkey")
+	uncompressed := fs.Bool("uncompressed", false, "emit the uncompressed point form")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("convert: want exactly one value")
+	}
+	b, err := decodeInput(fs.Arg(0), *from)
+	if err != nil {
+		return err
+	}
+	out, err := convertPoint(b, *typ, *uncompressed)
+	if err != nil {
+		return err
+	}
+	return encodeOutput(os.Stdout, out, *to)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// convertValue runs a value through the same steps as runConvert, writing raw
+// output to a file so it can be fed back in with --from raw.
+func convertValue(t *testing.T, value, from, to, typ string, uncompressed bool) string {
+	t.Helper()
+	b, err := decodeInput(value, from)
+	if err != nil {
+		t.Fatalf("decode from %s: %v", from, err)
+	}
+	out, err := convertPoint(b, typ, uncompressed)
+	if err != nil {
+		t.Fatalf("convert %s: %v", typ, err)
+	}
+	var buf bytes.Buffer
+	if err := encodeOutput(&buf, out, to); err != nil {
+		t.Fatal(err)
+	}
+	if to != "raw" {
+		return strings.TrimSpace(buf.String())
+	}
+	path := filepath.Join(t.TempDir(), "value.bin")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConvertSignature(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	sigHex := mustSign(t, skHex, "convert me")
+	formats := []string{"hex", "base64", "raw"}
+	for _, uncompressed := range []bool{false, true} {
+		for _, from := range formats {
+			for _, to := range formats {
+				// Bring the signature into the from format, convert it to the
+				// to format and back to compressed hex, which must be unchanged.
+				in := convertValue(t, sigHex, "hex", from, "signature", uncompressed)
+				mid := convertValue(t, in, from, to, "signature", uncompressed)
+				if got := convertValue(t, mid, to, "hex", "signature", false); got != sigHex {
+					t.Fatalf("%s -> %s (uncompressed=%v): got %s, want %s", from, to, uncompressed, got, sigHex)
+				}
+			}
+		}
+	}
+}
+
+func TestConvertRejectsWrongType(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	if got := convertValue(t, pubKeyHex, "hex", "hex", "pubkey", false); got != pubKeyHex {
+		t.Fatalf("pubkey round trip: got %s, want %s", got, pubKeyHex)
+	}
+	pk, err := decodeHex(pubKeyHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := convertPoint(pk, "signature", false); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("pubkey as signature: err = %v, want ErrInvalidSignature", err)
+	}
+	sig, err := decodeHex(mustSign(t, skHex, "x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := convertPoint(sig, "pubkey", false); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Fatalf("signature as pubkey: err = %v, want ErrInvalidPublicKey", err)
+	}
+	if _, err := decodeInput("00", "base58"); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("unknown format: err = %v, want ErrUnknownFormat", err)
+	}
+}
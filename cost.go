@@ -0,0 +1,76 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+)
+
+const (
+	calibrationSigners = 64
+	calibrationRounds  = 5
+)
+
+// verifyCost models FastAggregateVerify as a fixed pairing cost plus a
+// per-signer public key aggregation cost.
+type verifyCost struct {
+	mu        sync.Mutex
+	once      sync.Once
+	base      time.Duration
+	perSigner time.Duration
+}
+
+var costModel verifyCost
+
+// CalibrateVerifyCost times FastAggregateVerify on this machine and updates
+// the model used by EstimateVerifyCost. It takes a few milliseconds.
+func CalibrateVerifyCost() {
+	skHex, _ := ExampleKeyPair()
+	sk, err := secretKeyFromHex(skHex)
+	if err != nil {
+		return
+	}
+	root := signingRoot([]byte("bls-sig calibration"))
+	sig := sk.Sign(root[:])
+	pks := make([]common.PublicKey, calibrationSigners)
+	for i := range pks {
+		pks[i] = sk.PublicKey()
+	}
+
+	single := minVerifyTime(sig, pks[:1], root)
+	many := minVerifyTime(sig, pks, root)
+	perSigner := (many - single) / (calibrationSigners - 1)
+	if perSigner < 0 {
+		perSigner = 0
+	}
+
+	costModel.mu.Lock()
+	costModel.base = single - perSigner
+	costModel.perSigner = perSigner
+	costModel.mu.Unlock()
+}
+
+func minVerifyTime(sig common.Signature, pks []common.PublicKey, root [32]byte) time.Duration {
+	var best time.Duration
+	for i := 0; i < calibrationRounds; i++ {
+		start := time.Now()
+		sig.FastAggregateVerify(pks, root)
+		if d := time.Since(start); i == 0 || d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// EstimateVerifyCost estimates how long FastAggregateVerify takes for an
+// aggregate of signerCount signers. The model is calibrated on first use.
+func EstimateVerifyCost(signerCount int) time.Duration {
+	costModel.once.Do(CalibrateVerifyCost)
+	if signerCount < 1 {
+		signerCount = 1
+	}
+	costModel.mu.Lock()
+	defer costModel.mu.Unlock()
+	return costModel.base + time.Duration(signerCount)*costModel.perSigner
+}
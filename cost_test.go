@@ -0,0 +1,18 @@
+package main
+
+import "testing"
+
+func TestEstimateVerifyCostScalesLinearly(t *testing.T) {
+	one := EstimateVerifyCost(1)
+	if one <= 0 {
+		t.Fatalf("EstimateVerifyCost(1) = %v, want positive", one)
+	}
+	d100 := EstimateVerifyCost(101) - one
+	d200 := EstimateVerifyCost(201) - one
+	if d100 < 0 || d200 != 2*d100 {
+		t.Fatalf("growth over 100 signers %v, over 200 %v; want the second twice the first", d100, d200)
+	}
+	if EstimateVerifyCost(0) != one {
+		t.Fatal("signer counts below 1 should be treated as 1")
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrDayNotAllowed = errors.New("signature day is not allowed")
+
+// dailyDomain separates day-scoped signatures from signatures over ordinary messages.
+var dailyDomain = []byte("bls-sig daily")
+
+func dailyRoot(msg []byte, day int64) [32]byte {
+	var d [8]byte
+	binary.BigEndian.PutUint64(d[:], uint64(day))
+	return BuildSigningInput(dailyDomain, d[:], msg)
+}
+
+// SignDaily signs msg under a domain that includes day, so a leaked signature
+// is only useful to verifiers that still accept that day.
+func SignDaily(skHex string, msg []byte, day int64) (string, error) {
+	return signRoot(skHex, dailyRoot(msg, day))
+}
+
+// VerifyDaily checks a SignDaily signature made on day. It returns
+// ErrDayNotAllowed without verifying if day is not in allowedDays.
+func VerifyDaily(pubKeyHex, sigHex string, msg []byte, day int64, allowedDays []int64) (bool, error) {
+	if !slices.Contains(allowedDays, day) {
+		return false, fmt.Errorf("%w: %d", ErrDayNotAllowed, day)
+	}
+	return verifyRoot(pubKeyHex, sigHex, dailyRoot(msg, day))
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyDaily(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("session token")
+	sig, err := SignDaily(skHex, msg, 20375)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := []int64{20375, 20376}
+	if ok, err := VerifyDaily(pubKeyHex, sig, msg, 20375, allowed); err != nil || !ok {
+		t.Fatalf("allowed day: %v, %v; want true", ok, err)
+	}
+	if _, err := VerifyDaily(pubKeyHex, sig, msg, 20375, []int64{20376}); !errors.Is(err, ErrDayNotAllowed) {
+		t.Fatalf("day not allowed: err = %v, want ErrDayNotAllowed", err)
+	}
+	// Claiming an allowed day does not help a signature from another day.
+	if ok, err := VerifyDaily(pubKeyHex, sig, msg, 20376, allowed); err != nil || ok {
+		t.Fatalf("signature replayed on another day: %v, %v; want false", ok, err)
+	}
+}
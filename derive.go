@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var (
+	ErrSeedTooShort    = errors.New("seed must be at least 32 bytes")
+	ErrInvalidKeyCount = errors.New("key count must be positive")
+)
+
+// DerivedKey is one validator key from DeriveKeyTree, with the EIP-2334 path
+// it was derived at.
+type DerivedKey struct {
+	Path      string `json:"path"`
+	SecretKey string `json:"secret_key"`
+	PublicKey string `json:"pubkey"`
+}
+
+// eip2334Purpose and eip2334CoinType are the fixed m/12381/3600 path prefix
+// for Ethereum validator keys.
+const (
+	eip2334Purpose  = 12381
+	eip2334CoinType = 3600
+)
+
+// DeriveKeyTree derives count signing keys from seed with EIP-2333, the i-th at
+// m/12381/3600/i/0/0, for provisioning a batch of validators.
+func DeriveKeyTree(seed []byte, count int) ([]DerivedKey, error) {
+	if len(seed) < 32 {
+		return nil, ErrSeedTooShort
+	}
+	if count <= 0 {
+		return nil, ErrInvalidKeyCount
+	}
+	master := blst.DeriveMasterEip2333(seed)
+	if master == nil {
+		return nil, ErrInvalidSecretKey
+	}
+	defer master.Zeroize()
+	base := deriveChildPath(master, eip2334Purpose, eip2334CoinType)
+	defer base.Zeroize()
+
+	keys := make([]DerivedKey, count)
+	for i := range keys {
+		sk := deriveChildPath(base, uint32(i), 0, 0)
+		pk := new(blst.P1Affine).From(sk)
+		keys[i] = DerivedKey{
+			Path:      fmt.Sprintf("m/%d/%d/%d/0/0", eip2334Purpose, eip2334CoinType, i),
+			SecretKey: EncodingEthereum.Encode(sk.Serialize()),
+			PublicKey: EncodingEthereum.Encode(pk.Compress()),
+		}
+		sk.Zeroize()
+	}
+	return keys, nil
+}
+
+// deriveChildPath walks indices from parent, wiping each intermediate key.
+func deriveChildPath(parent *blst.SecretKey, indices ...uint32) *blst.SecretKey {
+	sk := parent
+	for _, idx := range indices {
+		child := sk.DeriveChildEip2333(idx)
+		if sk != parent {
+			sk.Zeroize()
+		}
+		sk = child
+	}
+	return sk
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// EIP-2333 test cases 0 and 1, with keys as decimal integers as in the spec.
+var eip2333Vectors = []struct {
+	seed       string
+	master     string
+	childIndex uint32
+	child      string
+}{
+	{
+		seed:       "0xc55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		master:     "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		childIndex: 0,
+		child:      "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+	},
+	{
+		seed:       "0x3141592653589793238462643383279502884197169399375105820974944592",
+		master:     "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		childIndex: 3141592653,
+		child:      "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+	},
+}
+
+func secretKeyInt(sk *blst.SecretKey) string {
+	return new(big.Int).SetBytes(sk.Serialize()).String()
+}
+
+func TestEIP2333Vectors(t *testing.T) {
+	for i, v := range eip2333Vectors {
+		seed, err := decodeHex(v.seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		master := blst.DeriveMasterEip2333(seed)
+		if got := secretKeyInt(master); got != v.master {
+			t.Fatalf("case %d: master %s, want %s", i, got, v.master)
+		}
+		if got := secretKeyInt(deriveChildPath(master, v.childIndex)); got != v.child {
+			t.Fatalf("case %d: child %s, want %s", i, got, v.child)
+		}
+	}
+}
+
+func TestDeriveKeyTree(t *testing.T) {
+	seed, err := decodeHex(eip2333Vectors[0].seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := DeriveKeyTree(seed, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	master := blst.DeriveMasterEip2333(seed)
+	seen := make(map[string]bool)
+	for i, k := range keys {
+		if want := fmt.Sprintf("m/12381/3600/%d/0/0", i); k.Path != want {
+			t.Fatalf("key %d path %s, want %s", i, k.Path, want)
+		}
+		want := deriveChildPath(master, 12381, 3600, uint32(i), 0, 0)
+		if k.SecretKey != EncodingEthereum.Encode(want.Serialize()) {
+			t.Fatalf("key %d does not match a direct derivation of %s", i, k.Path)
+		}
+		if mustPubKey(t, k.SecretKey) != k.PublicKey {
+			t.Fatalf("key %d public key does not match its secret key", i)
+		}
+		if seen[k.SecretKey] {
+			t.Fatalf("key %d repeats an earlier key", i)
+		}
+		seen[k.SecretKey] = true
+	}
+}
+
+func TestDerivedKeyJSON(t *testing.T) {
+	got, err := json.Marshal(DerivedKey{Path: "m/12381/3600/0/0/0", SecretKey: "0x01", PublicKey: "0x02"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"path":"m/12381/3600/0/0/0","secret_key":"0x01","pubkey":"0x02"}`; string(got) != want {
+		t.Fatalf("DerivedKey JSON = %s, want %s", got, want)
+	}
+}
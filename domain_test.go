@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVerifyMultiDomain(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sigA, err := SignWithDomain(skHex, []byte("login"), "app-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigB, err := SignWithDomain(skHex, []byte("login"), "app-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := VerifyMultiDomain([]DomainTuple{
+		{PubKey: pubKeyHex, Signature: sigA, Message: "login", Domain: "app-a"},
+		{PubKey: pubKeyHex, Signature: sigB, Message: "login", Domain: "app-a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []bool{true, false}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("VerifyMultiDomain = %v, want %v", got, want)
+	}
+}
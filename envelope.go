@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Envelope layout, all fields big-endian:
+//
+//	magic "BLSE" | version (1) | scheme (1) | length (2) | signature | checksum (4)
+//
+// The checksum is the first four bytes of SHA-256 over everything before it.
+const (
+	envelopeVersion     = 1
+	envelopeHeaderLen   = 8
+	envelopeChecksumLen = 4
+)
+
+var envelopeMagic = []byte("BLSE")
+
+var (
+	ErrInvalidEnvelope  = errors.New("invalid signature envelope")
+	ErrEnvelopeVersion  = errors.New("unsupported envelope version")
+	ErrEnvelopeChecksum = errors.New("envelope checksum mismatch")
+)
+
+// EncodeEnvelope wraps a signature with the scheme it was made under and its
+// length, so an archived signature can be interpreted without outside context.
+func EncodeEnvelope(sigHex string, scheme Scheme) (string, error) {
+	if _, err := scheme.DST(); err != nil {
+		return "", err
+	}
+	sig, err := decodeHex(sigHex)
+	if err != nil {
+		return "", fmt.Errorf("signature: %w", err)
+	}
+	if _, err := decodeSignaturePoint(sig); err != nil {
+		return "", err
+	}
+
+	env := make([]byte, 0, envelopeHeaderLen+len(sig)+envelopeChecksumLen)
+	env = append(env, envelopeMagic...)
+	env = append(env, envelopeVersion, byte(scheme))
+	env = binary.BigEndian.AppendUint16(env, uint16(len(sig)))
+	env = append(env, sig...)
+	sum := sha256.Sum256(env)
+	env = append(env, sum[:envelopeChecksumLen]...)
+	return EncodingEthereum.Encode(env), nil
+}
+
+// DecodeEnvelope validates an envelope produced by EncodeEnvelope and returns
+// the signature and scheme it carries.
+func DecodeEnvelope(envHex string) (string, Scheme, error) {
+	env, err := decodeHex(envHex)
+	if err != nil {
+		return "", 0, fmt.Errorf("envelope: %w", err)
+	}
+	if len(env) < envelopeHeaderLen+envelopeChecksumLen || !bytes.Equal(env[:4], envelopeMagic) {
+		return "", 0, ErrInvalidEnvelope
+	}
+	body, checksum := env[:len(env)-envelopeChecksumLen], env[len(env)-envelopeChecksumLen:]
+	sum := sha256.Sum256(body)
+	if !bytes.Equal(checksum, sum[:envelopeChecksumLen]) {
+		return "", 0, ErrEnvelopeChecksum
+	}
+	if body[4] != envelopeVersion {
+		return "", 0, fmt.Errorf("%w: %d", ErrEnvelopeVersion, body[4])
+	}
+	scheme := Scheme(body[5])
+	if _, err := scheme.DST(); err != nil {
+		return "", 0, err
+	}
+	sig := body[envelopeHeaderLen:]
+	if n := int(binary.BigEndian.Uint16(body[6:8])); n != len(sig) {
+		return "", 0, fmt.Errorf("%w: length field says %d bytes, got %d", ErrInvalidEnvelope, n, len(sig))
+	}
+	if _, err := decodeSignaturePoint(sig); err != nil {
+		return "", 0, err
+	}
+	return EncodingEthereum.Encode(sig), scheme, nil
+}
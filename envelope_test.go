@@ -0,0 +1,65 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	sig := mustSign(t, skHex, "archive me")
+	env, err := EncodeEnvelope(sig, SchemePOP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, scheme, err := DecodeEnvelope(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != sig || scheme != SchemePOP {
+		t.Fatalf("DecodeEnvelope = %s, 0x%02x; want %s, 0x%02x", got, byte(scheme), sig, byte(SchemePOP))
+	}
+}
+
+func TestEnvelopeCorruption(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	env, err := EncodeEnvelope(mustSign(t, skHex, "archive me"), SchemePOP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := decodeHex(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flip := func(i int) string {
+		b := append([]byte(nil), raw...)
+		b[i] ^= 0x01
+		return EncodingEthereum.Encode(b)
+	}
+	// Rewrite the version byte and recompute the checksum, so only the
+	// version check can catch it.
+	future := append([]byte(nil), raw[:len(raw)-envelopeChecksumLen]...)
+	future[4] = envelopeVersion + 1
+	sum := sha256.Sum256(future)
+	future = append(future, sum[:envelopeChecksumLen]...)
+
+	tests := []struct {
+		name string
+		env  string
+		err  error
+	}{
+		{"magic", flip(0), ErrInvalidEnvelope},
+		{"signature byte", flip(envelopeHeaderLen + 10), ErrEnvelopeChecksum},
+		{"checksum", flip(len(raw) - 1), ErrEnvelopeChecksum},
+		{"truncated", EncodingEthereum.Encode(raw[:6]), ErrInvalidEnvelope},
+		{"unknown version", EncodingEthereum.Encode(future), ErrEnvelopeVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeEnvelope(tt.env); !errors.Is(err, tt.err) {
+				t.Fatalf("DecodeEnvelope: err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
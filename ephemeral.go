@@ -0,0 +1,101 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var ErrSignerExpired = errors.New("ephemeral signer has expired")
+
+// EphemeralSigner is a Signer whose key exists only in memory and is
+// zeroized on Close or once its TTL passes, after which Sign returns
+// ErrSignerExpired. The key is never exported or persisted.
+type EphemeralSigner struct {
+	mu       sync.Mutex
+	sk       *blst.SecretKey
+	pubHex   string
+	deadline time.Time
+	timer    *time.Timer
+}
+
+var _ Signer = (*EphemeralSigner)(nil)
+
+// NewEphemeralSigner generates a fresh key with no TTL; it lives until Close
+// unless SetTTL is called.
+func NewEphemeralSigner() (*EphemeralSigner, error) {
+	ikm := make([]byte, 32)
+	defer zeroize(ikm)
+	if _, err := rand.Read(ikm); err != nil {
+		return nil, err
+	}
+	sk := blst.KeyGen(ikm)
+	pub := new(blst.P1Affine).From(sk).Compress()
+	return &EphemeralSigner{sk: sk, pubHex: EncodingEthereum.Encode(pub)}, nil
+}
+
+// SetTTL makes the key expire d from now, replacing any earlier TTL.
+func (s *EphemeralSigner) SetTTL(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sk == nil {
+		return
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.deadline = time.Now().Add(d)
+	s.timer = time.AfterFunc(d, func() { s.Close() })
+}
+
+// PublicKeyHex returns the signer's public key as hex. It stays available
+// after the key expires.
+func (s *EphemeralSigner) PublicKeyHex() string {
+	return s.pubHex
+}
+
+// PublicKey implements Signer.
+func (s *EphemeralSigner) PublicKey() (string, error) {
+	return s.pubHex, nil
+}
+
+// Sign implements Signer, signing like GenerateSignature. It returns
+// ErrSignerExpired once the signer is closed or past its TTL.
+func (s *EphemeralSigner) Sign(msg []byte) (string, error) {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sk != nil && !s.deadline.IsZero() && !time.Now().Before(s.deadline) {
+		s.closeLocked()
+	}
+	if s.sk == nil {
+		return "", ErrSignerExpired
+	}
+	root := signingRoot(msg)
+	sig := new(blst.P2Affine).Sign(s.sk, root[:], schemeDSTs[SchemePOP])
+	return EncodingEthereum.Encode(sig.Compress()), nil
+}
+
+// Close zeroizes the key. It is safe to call more than once.
+func (s *EphemeralSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+	return nil
+}
+
+func (s *EphemeralSigner) closeLocked() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if s.sk != nil {
+		s.sk.Zeroize()
+		s.sk = nil
+	}
+}
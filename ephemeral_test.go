@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEphemeralSignerTTL(t *testing.T) {
+	s, err := NewEphemeralSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetTTL(time.Hour)
+	sig, err := s.Sign([]byte("short-lived"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifySignature(s.PublicKeyHex(), sig, "short-lived"); err != nil || !ok {
+		t.Fatalf("signature before expiry: ok=%v err=%v", ok, err)
+	}
+
+	s.SetTTL(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, err := s.Sign([]byte("too late")); !errors.Is(err, ErrSignerExpired) {
+		t.Fatalf("after TTL: err = %v, want ErrSignerExpired", err)
+	}
+	if pk, err := s.PublicKey(); err != nil || pk != s.PublicKeyHex() {
+		t.Fatalf("public key after expiry: %q, %v", pk, err)
+	}
+}
+
+func TestEphemeralSignerClose(t *testing.T) {
+	s, err := NewEphemeralSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+	if _, err := s.Sign([]byte("closed")); !errors.Is(err, ErrSignerExpired) {
+		t.Fatalf("after Close: err = %v, want ErrSignerExpired", err)
+	}
+}
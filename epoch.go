@@ -0,0 +1,60 @@
+package main
+
+import "sync"
+
+// CommitteeFunc returns the committee's public keys for an epoch.
+type CommitteeFunc func(epoch uint64) ([]string, error)
+
+// EpochVerifier verifies same-message aggregates from one committee, whose
+// membership changes only at epoch boundaries. It aggregates the committee's
+// public keys once per epoch and reuses the result for every verification
+// until SetEpoch moves to a new epoch. It is safe for concurrent use.
+type EpochVerifier struct {
+	mu        sync.Mutex
+	committee CommitteeFunc
+	epoch     uint64
+	aggPubKey string // empty until computed for epoch
+}
+
+// NewEpochVerifier returns an EpochVerifier starting at epoch that looks up
+// committees with committee.
+func NewEpochVerifier(committee CommitteeFunc, epoch uint64) *EpochVerifier {
+	return &EpochVerifier{committee: committee, epoch: epoch}
+}
+
+// SetEpoch moves to epoch, dropping the cached aggregate key if it changes.
+func (v *EpochVerifier) SetEpoch(epoch uint64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if epoch != v.epoch {
+		v.epoch = epoch
+		v.aggPubKey = ""
+	}
+}
+
+// Verify checks aggSigHex over msg against the current epoch's committee.
+func (v *EpochVerifier) Verify(aggSigHex, msg string) (bool, error) {
+	aggPubKey, err := v.aggregateKey()
+	if err != nil {
+		return false, err
+	}
+	return VerifySignature(aggPubKey, aggSigHex, msg)
+}
+
+func (v *EpochVerifier) aggregateKey() (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.aggPubKey != "" {
+		return v.aggPubKey, nil
+	}
+	pks, err := v.committee(v.epoch)
+	if err != nil {
+		return "", err
+	}
+	agg, err := AggregatePublicKeys(pks)
+	if err != nil {
+		return "", err
+	}
+	v.aggPubKey = agg
+	return agg, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestEpochVerifier(t *testing.T) {
+	const msg = "attestation"
+	_, pks0, sigs0 := testSigners(t, 3, msg)
+	_, pks1, sigs1 := testSigners(t, 3, msg)
+	committees := map[uint64][]string{0: pks0, 1: pks1}
+	lookups := map[uint64]int{}
+	v := NewEpochVerifier(func(epoch uint64) ([]string, error) {
+		lookups[epoch]++
+		return committees[epoch], nil
+	}, 0)
+
+	agg0 := mustAggregate(t, sigs0)
+	for i := 0; i < 3; i++ {
+		if ok, err := v.Verify(agg0, msg); err != nil || !ok {
+			t.Fatalf("epoch 0 verify %d: ok=%v err=%v", i, ok, err)
+		}
+	}
+	if lookups[0] != 1 {
+		t.Fatalf("epoch 0 committee looked up %d times, want 1", lookups[0])
+	}
+
+	v.SetEpoch(0)
+	if _, err := v.Verify(agg0, msg); err != nil {
+		t.Fatal(err)
+	}
+	if lookups[0] != 1 {
+		t.Fatalf("SetEpoch to the same epoch dropped the cache")
+	}
+
+	v.SetEpoch(1)
+	if ok, err := v.Verify(agg0, msg); err != nil || ok {
+		t.Fatalf("epoch 0 aggregate after rollover: ok=%v err=%v", ok, err)
+	}
+	if ok, err := v.Verify(mustAggregate(t, sigs1), msg); err != nil || !ok {
+		t.Fatalf("epoch 1 verify: ok=%v err=%v", ok, err)
+	}
+	if lookups[1] != 1 {
+		t.Fatalf("epoch 1 committee looked up %d times, want 1", lookups[1])
+	}
+}
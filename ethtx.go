@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// ethTxDST is the domain separation tag for signatures over Ethereum
+// transaction hashes. It differs from every scheme DST, so such a signature
+// never verifies as an ordinary message signature.
+var ethTxDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_ETH_TX_")
+
+// SignEthTxHash signs an Ethereum transaction hash, such as the keccak256 of
+// a typed transaction's signing payload. The hash is signed as given, without
+// hashing it again.
+func SignEthTxHash(skHex string, txHash [32]byte) (string, error) {
+	sk, err := blstSecretKeyFromHex(skHex)
+	if err != nil {
+		return "", err
+	}
+	defer sk.Zeroize()
+	sig := new(blst.P2Affine).Sign(sk, txHash[:], ethTxDST)
+	return EncodingEthereum.Encode(sig.Compress()), nil
+}
+
+// VerifyEthTxHash checks a SignEthTxHash signature over txHash.
+func VerifyEthTxHash(pubKeyHex, sigHex string, txHash [32]byte) (bool, error) {
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return false, fmt.Errorf("signature: %w", err)
+	}
+	if err := checkSignatureLength(b, SignatureLength); err != nil {
+		return false, err
+	}
+	sig, err := decodeSignaturePoint(b)
+	if err != nil {
+		return false, err
+	}
+	pk, err := blstPublicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	return sig.Verify(false, pk, false, txHash[:], ethTxDST), nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEthTxHash(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	var txHash [32]byte
+	b, err := hex.DecodeString("5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060")
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(txHash[:], b)
+
+	sig, err := SignEthTxHash(skHex, txHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyEthTxHash(pubKeyHex, sig, txHash); err != nil || !ok {
+		t.Fatalf("VerifyEthTxHash: ok=%v err=%v", ok, err)
+	}
+	other := txHash
+	other[0] ^= 1
+	if ok, err := VerifyEthTxHash(pubKeyHex, sig, other); err != nil || ok {
+		t.Fatalf("other hash: ok=%v err=%v", ok, err)
+	}
+	// The dedicated DST keeps the two kinds of signature apart.
+	if ok, err := verifyRoot(pubKeyHex, sig, txHash); err != nil || ok {
+		t.Fatalf("verified under the message DST: ok=%v err=%v", ok, err)
+	}
+}
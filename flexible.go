@@ -0,0 +1,52 @@
+package main
+
+// MessageForm says how a message string was interpreted when it verified.
+type MessageForm int
+
+const (
+	// MessageFormNone means no interpretation verified.
+	MessageFormNone MessageForm = iota
+	// MessageFormUTF8 means the raw bytes of the string verified.
+	MessageFormUTF8
+	// MessageFormHex means the hex-decoded bytes of the string verified.
+	MessageFormHex
+)
+
+func (f MessageForm) String() string {
+	switch f {
+	case MessageFormUTF8:
+		return "utf8"
+	case MessageFormHex:
+		return "hex"
+	default:
+		return "none"
+	}
+}
+
+// VerifyFlexible is VerifyFlexibleForm reduced to a boolean.
+func VerifyFlexible(pubKeyHex, sigHex, msg string) (bool, error) {
+	form, err := VerifyFlexibleForm(pubKeyHex, sigHex, msg)
+	return form != MessageFormNone, err
+}
+
+// VerifyFlexibleForm verifies sigHex over the UTF-8 bytes of msg and, if msg
+// also parses as hex, over the decoded bytes, reporting which one matched.
+// It papers over signers that disagree on how a message string is encoded.
+func VerifyFlexibleForm(pubKeyHex, sigHex, msg string) (MessageForm, error) {
+	ok, err := verifyRoot(pubKeyHex, sigHex, signingRoot([]byte(msg)))
+	if err != nil {
+		return MessageFormNone, err
+	}
+	if ok {
+		return MessageFormUTF8, nil
+	}
+	decoded, err := decodeHex(msg)
+	if err != nil || len(decoded) == 0 {
+		return MessageFormNone, nil
+	}
+	ok, err = verifyRoot(pubKeyHex, sigHex, signingRoot(decoded))
+	if err != nil || !ok {
+		return MessageFormNone, err
+	}
+	return MessageFormHex, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifyFlexibleHexOnly(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	raw := []byte{0xde, 0xad, 0xbe, 0xef}
+	sig, err := GenerateSignature(skHex, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form, err := VerifyFlexibleForm(pubKeyHex, sig, hex.EncodeToString(raw))
+	if err != nil || form != MessageFormHex {
+		t.Fatalf("hex-encoded message: form %v, %v; want hex", form, err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, sig, hex.EncodeToString(raw)); err != nil || ok {
+		t.Fatalf("UTF-8 interpretation verified: %v, %v", ok, err)
+	}
+
+	utf8Sig := mustSign(t, skHex, "plain text")
+	if form, err := VerifyFlexibleForm(pubKeyHex, utf8Sig, "plain text"); err != nil || form != MessageFormUTF8 {
+		t.Fatalf("UTF-8 message: form %v, %v; want utf8", form, err)
+	}
+	if ok, err := VerifyFlexible(pubKeyHex, utf8Sig, "0xdeadbeef"); err != nil || ok {
+		t.Fatalf("wrong message: %v, %v; want false", ok, err)
+	}
+}
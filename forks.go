@@ -0,0 +1,57 @@
+package main
+
+import "crypto/sha256"
+
+// DomainBeaconProposer is DOMAIN_BEACON_PROPOSER.
+var DomainBeaconProposer = [4]byte{0x00, 0x00, 0x00, 0x00}
+
+// computeDomain follows the consensus spec: the domain type followed by the
+// first 28 bytes of hash_tree_root(ForkData{version, genesisValidatorsRoot}).
+func computeDomain(domainType, forkVersion [4]byte, genesisValidatorsRoot [32]byte) [32]byte {
+	var forkData [64]byte
+	copy(forkData[:4], forkVersion[:])
+	copy(forkData[32:], genesisValidatorsRoot[:])
+	forkDataRoot := sha256.Sum256(forkData[:])
+
+	var domain [32]byte
+	copy(domain[:4], domainType[:])
+	copy(domain[4:], forkDataRoot[:28])
+	return domain
+}
+
+// computeForkSigningRoot is hash_tree_root(SigningData{objectRoot, domain}).
+func computeForkSigningRoot(objectRoot, domain [32]byte) [32]byte {
+	var data [64]byte
+	copy(data[:32], objectRoot[:])
+	copy(data[32:], domain[:])
+	return sha256.Sum256(data[:])
+}
+
+// VerifyAcrossForks checks sigHex over objectRoot under each fork version's
+// DomainBeaconProposer domain in turn and returns the index of the first that
+// verifies, or -1 if none do. It is meant for hard-fork transitions, when
+// either version may apply.
+func VerifyAcrossForks(pubKeyHex, sigHex string, objectRoot [32]byte, forkVersions [][4]byte, genesisValidatorsRoot [32]byte) (matchedForkIndex int, err error) {
+	return VerifyAcrossForksWithDomain(DomainBeaconProposer, pubKeyHex, sigHex, objectRoot, forkVersions, genesisValidatorsRoot)
+}
+
+// VerifyAcrossForksWithDomain is VerifyAcrossForks for objects signed under
+// domainType.
+func VerifyAcrossForksWithDomain(domainType [4]byte, pubKeyHex, sigHex string, objectRoot [32]byte, forkVersions [][4]byte, genesisValidatorsRoot [32]byte) (matchedForkIndex int, err error) {
+	pk, err := publicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return -1, err
+	}
+	sig, err := signatureFromHex(sigHex)
+	if err != nil {
+		return -1, err
+	}
+	for i, version := range forkVersions {
+		domain := computeDomain(domainType, version, genesisValidatorsRoot)
+		root := computeForkSigningRoot(objectRoot, domain)
+		if sig.Verify(pk, root[:]) {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
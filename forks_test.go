@@ -0,0 +1,29 @@
+package main
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestVerifyAcrossForksMatchesSecondFork(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	objectRoot := sha256.Sum256([]byte("block"))
+	gvr := sha256.Sum256([]byte("genesis"))
+	forks := [][4]byte{{0x03, 0, 0, 0}, {0x04, 0, 0, 0}}
+
+	domain := computeDomain(DomainBeaconProposer, forks[1], gvr)
+	sig, err := signRoot(skHex, computeForkSigningRoot(objectRoot, domain))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := VerifyAcrossForks(pubKeyHex, sig, objectRoot, forks, gvr); err != nil || got != 1 {
+		t.Fatalf("VerifyAcrossForks = %d, %v; want 1", got, err)
+	}
+	if got, err := VerifyAcrossForks(pubKeyHex, sig, objectRoot, forks[:1], gvr); err != nil || got != -1 {
+		t.Fatalf("first fork only: %d, %v; want -1", got, err)
+	}
+	other := [4]byte{0x01, 0, 0, 0}
+	if got, err := VerifyAcrossForksWithDomain(other, pubKeyHex, sig, objectRoot, forks, gvr); err != nil || got != -1 {
+		t.Fatalf("other domain type: %d, %v; want -1", got, err)
+	}
+}
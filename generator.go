@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var ErrInvalidGenerator = errors.New("generator is not a non-identity G1 subgroup point")
+
+// Generator is the G1 base point public keys are derived from. Every
+// non-identity point of the prime-order subgroup generates it, so any such
+// point can stand in for the standard one, as long as signers and verifiers
+// agree. Signatures don't depend on the generator; public keys and
+// verification do.
+type Generator struct {
+	p *blst.P1Affine
+}
+
+// DefaultGenerator returns the standard BLS12-381 G1 generator.
+func DefaultGenerator() *Generator {
+	return &Generator{p: blst.P1Generator().ToAffine()}
+}
+
+// NewGenerator parses a compressed G1 point and checks it is a valid
+// subgroup generator.
+func NewGenerator(pointHex string) (*Generator, error) {
+	b, err := decodeHex(pointHex)
+	if err != nil {
+		return nil, fmt.Errorf("generator: %w", err)
+	}
+	p := new(blst.P1Affine).Uncompress(b)
+	if p == nil || !p.KeyValidate() {
+		return nil, ErrInvalidGenerator
+	}
+	return &Generator{p: p}, nil
+}
+
+// Hex returns the generator as compressed hex.
+func (g *Generator) Hex() string {
+	return EncodingEthereum.Encode(g.p.Compress())
+}
+
+// PublicKey derives sk·G for the secret key skHex.
+func (g *Generator) PublicKey(skHex string) (string, error) {
+	sk, err := blstSecretKeyFromHex(skHex)
+	if err != nil {
+		return "", err
+	}
+	defer sk.Zeroize()
+	var p blst.P1
+	p.FromAffine(g.p)
+	return EncodingEthereum.Encode(p.Mult(sk).ToAffine().Compress()), nil
+}
+
+// Verify checks a GenerateSignature signature against a public key derived
+// from this generator, testing e(pk, H(m)) = e(G, sig).
+func (g *Generator) Verify(pubKeyHex, sigHex, msg string) (bool, error) {
+	pk, err := blstPublicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return false, fmt.Errorf("signature: %w", err)
+	}
+	sig, err := decodeSignaturePoint(b)
+	if err != nil {
+		return false, err
+	}
+	root := signingRoot([]byte(msg))
+	h := blst.HashToG2(root[:], schemeDSTs[SchemePOP]).ToAffine()
+	return blst.Fp12FinalVerify(blst.Fp12MillerLoop(h, pk), blst.Fp12MillerLoop(sig, g.p)), nil
+}
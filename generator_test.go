@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCustomGenerator(t *testing.T) {
+	// Any valid public key is a non-identity subgroup point.
+	_, point := testKeyPair(t)
+	custom, err := NewGenerator(point)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skHex, _ := testKeyPair(t)
+	pubKeyHex, err := custom.PublicKey(skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := mustSign(t, skHex, "experiment")
+
+	if ok, err := custom.Verify(pubKeyHex, sig, "experiment"); err != nil || !ok {
+		t.Fatalf("same generator: %v, %v; want true", ok, err)
+	}
+	if ok, err := DefaultGenerator().Verify(pubKeyHex, sig, "experiment"); err != nil || ok {
+		t.Fatalf("mismatched generator: %v, %v; want false", ok, err)
+	}
+	if ok, err := DefaultGenerator().Verify(mustPubKey(t, skHex), sig, "experiment"); err != nil || !ok {
+		t.Fatalf("default generator: %v, %v; want true", ok, err)
+	}
+	identity := "0xc0" + strings.Repeat("00", PubKeyLength-1)
+	if _, err := NewGenerator(identity); !errors.Is(err, ErrInvalidGenerator) {
+		t.Fatalf("identity point: err = %v, want ErrInvalidGenerator", err)
+	}
+}
@@ -4,7 +4,12 @@ go 1.22.2
 
 require (
 	github.com/ethereum/go-ethereum v1.14.5
+	github.com/mattn/go-sqlite3 v1.14.22
 	github.com/prysmaticlabs/prysm/v5 v5.0.3
+	github.com/supranational/blst v0.3.11
+	golang.org/x/crypto v0.22.0
+	golang.org/x/text v0.14.0
+	google.golang.org/protobuf v1.33.0
 )
 
 require (
@@ -24,10 +29,7 @@ require (
 	github.com/prysmaticlabs/fastssz v0.0.0-20221107182844-78142813af44 // indirect
 	github.com/prysmaticlabs/gohashtree v0.0.4-beta // indirect
 	github.com/sirupsen/logrus v1.9.0 // indirect
-	github.com/supranational/blst v0.3.11 // indirect
 	github.com/thomaso-mirodin/intmath v0.0.0-20160323211736-5dc6d854e46e // indirect
-	golang.org/x/crypto v0.22.0 // indirect
 	golang.org/x/sys v0.20.0 // indirect
-	google.golang.org/protobuf v1.33.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
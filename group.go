@@ -0,0 +1,28 @@
+package main
+
+// groupDomain separates group identities from other hashes of a signer set.
+var groupDomain = []byte("bls-sig group identity")
+
+// GroupIdentity names a committee: groupIDHex is a hash binding name to the
+// sorted signer set and its aggregate public key, so the same keys in any
+// order or hex style give the same ID. A verifier recomputes it from the
+// claimed members to confirm the membership hasn't changed.
+func GroupIdentity(name string, pubKeyHexes []string) (groupIDHex string, aggPubKeyHex string, err error) {
+	if len(pubKeyHexes) == 0 {
+		return "", "", ErrNoPublicKeys
+	}
+	set, err := signerSetKey(pubKeyHexes)
+	if err != nil {
+		return "", "", err
+	}
+	aggPubKeyHex, err = AggregatePublicKeys(pubKeyHexes)
+	if err != nil {
+		return "", "", err
+	}
+	aggPub, err := decodeHex(aggPubKeyHex)
+	if err != nil {
+		return "", "", err
+	}
+	id := BuildSigningInput(groupDomain, []byte(name), set[:], aggPub)
+	return EncodingEthereum.Encode(id[:]), aggPubKeyHex, nil
+}
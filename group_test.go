@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestGroupIdentity(t *testing.T) {
+	_, pks, _ := testSigners(t, 4, "unused")
+	id, agg, err := GroupIdentity("committee-a", pks[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, err := AggregatePublicKeys(pks[:3]); err != nil || agg != want {
+		t.Fatalf("aggregate key = %s, want %s (%v)", agg, want, err)
+	}
+
+	reordered, _, err := GroupIdentity("committee-a", []string{pks[2], pks[0], pks[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reordered != id {
+		t.Fatal("reordering the members changed the group ID")
+	}
+	grown, _, err := GroupIdentity("committee-a", pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grown == id {
+		t.Fatal("adding a signer left the group ID unchanged")
+	}
+	renamed, _, err := GroupIdentity("committee-b", pks[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renamed == id {
+		t.Fatal("renaming the group left the group ID unchanged")
+	}
+}
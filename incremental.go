@@ -0,0 +1,82 @@
+package main
+
+import "sync"
+
+// incrementalBatchSize is how many tuples an IncrementalVerifier queues before
+// checking them.
+const incrementalBatchSize = 64
+
+// IncrementalVerifier verifies a growing stream of tuples. Tuples are queued
+// and batch-verified with random coefficients every incrementalBatchSize
+// additions and whenever Valid is called; once any check fails it stays
+// invalid.
+type IncrementalVerifier struct {
+	mu      sync.Mutex
+	pending decodedBatch
+	count   int
+	invalid bool
+}
+
+// NewIncrementalVerifier returns an IncrementalVerifier with nothing added,
+// which is valid.
+func NewIncrementalVerifier() *IncrementalVerifier {
+	return &IncrementalVerifier{}
+}
+
+// Add queues a tuple. Malformed input returns an error and is not added.
+func (v *IncrementalVerifier) Add(pubKeyHex, sigHex, msg string) error {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return err
+	}
+	sig, err := decodeHex(sigHex)
+	if err == nil {
+		err = checkSignatureLength(sig, SignatureLength)
+	}
+	if err != nil {
+		return err
+	}
+	pk, err := publicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.pending.sigs = append(v.pending.sigs, sig)
+	v.pending.pks = append(v.pending.pks, pk)
+	v.pending.roots = append(v.pending.roots, signingRoot([]byte(msg)))
+	v.count++
+	if len(v.pending.sigs) >= incrementalBatchSize {
+		v.flush()
+	}
+	return nil
+}
+
+// Len returns the number of tuples added so far.
+func (v *IncrementalVerifier) Len() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.count
+}
+
+// Valid reports whether every tuple added so far verifies, checking any still
+// queued.
+func (v *IncrementalVerifier) Valid() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.flush()
+	return !v.invalid
+}
+
+// flush checks the queued tuples and clears the queue. v.mu must be held.
+func (v *IncrementalVerifier) flush() {
+	n := len(v.pending.sigs)
+	if n == 0 {
+		return
+	}
+	if !v.invalid {
+		ok, err := v.pending.verify(0, n)
+		v.invalid = err != nil || !ok
+	}
+	v.pending = decodedBatch{}
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestIncrementalVerifier(t *testing.T) {
+	v := NewIncrementalVerifier()
+	if !v.Valid() {
+		t.Fatal("empty verifier is invalid")
+	}
+	for i, e := range testTuples(t, 4) {
+		if err := v.Add(e.PubKey, e.Signature, e.Message); err != nil {
+			t.Fatal(err)
+		}
+		if !v.Valid() {
+			t.Fatalf("invalid after %d valid tuples", i+1)
+		}
+	}
+
+	bad := testTuples(t, 1)[0]
+	if err := v.Add(bad.PubKey, bad.Signature, "tampered"); err != nil {
+		t.Fatal(err)
+	}
+	if v.Valid() {
+		t.Fatal("valid after adding a bad tuple")
+	}
+	good := testTuples(t, 1)[0]
+	if err := v.Add(good.PubKey, good.Signature, good.Message); err != nil {
+		t.Fatal(err)
+	}
+	if v.Valid() || v.Len() != 6 {
+		t.Fatalf("after a later good tuple: valid=%v len=%d; want false, 6", v.Valid(), v.Len())
+	}
+}
+
+func TestIncrementalVerifierFlushesFullBatch(t *testing.T) {
+	e := testTuples(t, 1)[0]
+	v := NewIncrementalVerifier()
+	if err := v.Add(e.PubKey, e.Signature, "tampered"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < incrementalBatchSize; i++ {
+		if err := v.Add(e.PubKey, e.Signature, e.Message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The full batch was checked by Add itself, before any Valid call.
+	if len(v.pending.sigs) != 0 || !v.invalid {
+		t.Fatalf("after %d additions: %d queued, invalid=%v", incrementalBatchSize, len(v.pending.sigs), v.invalid)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build interop
+
+// The py_ecc interop test signs the same messages with the same keys here and
+// in py_ecc, the Ethereum reference implementation, and checks that the
+// signatures are byte-identical and that each side accepts the other's. It
+// needs python3 with py_ecc installed and is skipped otherwise:
+//
+//	pip install py_ecc
+//	go test -tags interop -run TestPyECCInterop .
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// pyECCScript signs argv[2] (hex) with the secret key argv[1] (hex) under the
+// proof-of-possession scheme, then prints the public key, the signature,
+// whether py_ecc verifies its own signature and whether it verifies the
+// signature argv[3] (hex) from this package.
+const pyECCScript = `
+import sys
+from py_ecc.bls import G2ProofOfPossession as bls
+sk = int(sys.argv[1], 16)
+msg = bytes.fromhex(sys.argv[2])
+sig = bls.Sign(sk, msg)
+pk = bls.SkToPk(sk)
+print(pk.hex())
+print(sig.hex())
+print(bls.Verify(pk, msg, sig))
+print(bls.Verify(pk, msg, bytes.fromhex(sys.argv[3])))
+`
+
+func requirePyECC(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not installed")
+	}
+	if err := exec.Command("python3", "-c", "import py_ecc").Run(); err != nil {
+		t.Skip("py_ecc not installed")
+	}
+}
+
+func TestPyECCInterop(t *testing.T) {
+	requirePyECC(t)
+	msgs := []string{"", "hello", strings.Repeat("long message ", 100)}
+	for k := 0; k < 3; k++ {
+		skHex, pubKeyHex := testKeyPair(t)
+		for _, msg := range msgs {
+			sig := mustSign(t, skHex, msg)
+			// GenerateSignature signs the SHA-256 root of msg, so that is
+			// the message py_ecc is given.
+			root := sha256.Sum256([]byte(msg))
+			out, err := exec.Command("python3", "-c", pyECCScript,
+				strings.TrimPrefix(skHex, "0x"), hex.EncodeToString(root[:]), strings.TrimPrefix(sig, "0x")).Output()
+			if err != nil {
+				t.Fatalf("py_ecc: %v", err)
+			}
+			lines := strings.Fields(string(out))
+			if len(lines) != 4 {
+				t.Fatalf("py_ecc printed %q", out)
+			}
+			pyPub, pySig, pySelf, pyOurs := "0x"+lines[0], "0x"+lines[1], lines[2], lines[3]
+
+			if pyPub != pubKeyHex {
+				t.Errorf("public key: py_ecc %s, ours %s", pyPub, pubKeyHex)
+			}
+			if pySig != sig {
+				t.Errorf("signature over %.20q: py_ecc %s, ours %s", msg, pySig, sig)
+			}
+			if pySelf != "True" || pyOurs != "True" {
+				t.Errorf("py_ecc verify over %.20q: own %s, ours %s", msg, pySelf, pyOurs)
+			}
+			if ok, err := VerifySignature(pubKeyHex, pySig, msg); err != nil || !ok {
+				t.Errorf("VerifySignature of py_ecc signature over %.20q: %v, %v", msg, ok, err)
+			}
+		}
+	}
+}
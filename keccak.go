@@ -0,0 +1,25 @@
+package main
+
+import "golang.org/x/crypto/sha3"
+
+// keccakRoot is the legacy Keccak-256 of msg, as the EVM's KECCAK256 opcode
+// computes it. This differs from the SHA-256 used by GenerateSignature, so the
+// two paths produce different signatures for the same message.
+func keccakRoot(msg []byte) [32]byte {
+	var root [32]byte
+	h := sha3.NewLegacyKeccak256()
+	h.Write(msg)
+	h.Sum(root[:0])
+	return root
+}
+
+// SignKeccak signs the Keccak-256 of msg, for on-chain verifiers that expect
+// EVM-style message hashing.
+func SignKeccak(skHex string, msg []byte) (string, error) {
+	return signRoot(skHex, keccakRoot(msg))
+}
+
+// VerifyKeccak checks a signature made by SignKeccak.
+func VerifyKeccak(pubKeyHex, sigHex string, msg []byte) (bool, error) {
+	return verifyRoot(pubKeyHex, sigHex, keccakRoot(msg))
+}
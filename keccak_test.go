@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestKeccakRoot(t *testing.T) {
+	// Keccak-256 of the empty string and of "abc".
+	tests := map[string]string{
+		"":    "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"abc": "0x4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+	}
+	for msg, want := range tests {
+		root := keccakRoot([]byte(msg))
+		if got := EncodingEthereum.Encode(root[:]); got != want {
+			t.Errorf("keccakRoot(%q) = %s, want %s", msg, got, want)
+		}
+	}
+}
+
+func TestSignKeccak(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("transfer")
+	sig, err := SignKeccak(skHex, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyKeccak(pubKeyHex, sig, msg); err != nil || !ok {
+		t.Fatalf("VerifyKeccak = %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, sig, string(msg)); err != nil || ok {
+		t.Fatalf("SHA-256 path accepted a Keccak signature: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// Encoding selects the textual form used for keys and signatures.
+type Encoding int
+
+const (
+	// EncodingEthereum is lowercase hex with a 0x prefix.
+	EncodingEthereum Encoding = iota
+	// EncodingNIST is uppercase hex without a prefix.
+	EncodingNIST
+)
+
+var (
+	ErrInvalidHex          = errors.New("invalid hex string")
+	ErrInvalidPublicKeys   = errors.New("invalid public keys")
+	ErrSecretKeyLength     = errors.New("secret key must be 32 bytes")
+	ErrSecretKeyOutOfRange = errors.New("secret key is not in range 0 < sk < r")
+)
+
+// Encode renders b in the encoding e.
+func (e Encoding) Encode(b []byte) string {
+	switch e {
+	case EncodingNIST:
+		return strings.ToUpper(hex.EncodeToString(b))
+	default:
+		return "0x" + hex.EncodeToString(b)
+	}
+}
+
+// decodeHex accepts hex in any supported Encoding: optional 0x/0X prefix, any case.
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHex, err)
+	}
+	return b, nil
+}
+
+// GenerateKeyPair returns a fresh secret key and its public key as 0x-prefixed hex.
+func GenerateKeyPair() (string, string, error) {
+	return GenerateKeyPairWithEncoding(EncodingEthereum)
+}
+
+// GenerateKeyPairWithEncoding is GenerateKeyPair with a chosen output encoding.
+func GenerateKeyPairWithEncoding(enc Encoding) (string, string, error) {
+	sk, err := bls.RandKey()
+	if err != nil {
+		return "", "", err
+	}
+	return enc.Encode(sk.Marshal()), enc.Encode(sk.PublicKey().Marshal()), nil
+}
+
+// secretKeyFromHex decodes a secret key and wipes the intermediate byte buffer.
+// Errors never echo the input.
+func secretKeyFromHex(skHex string) (common.SecretKey, error) {
+	b, err := decodeHex(skHex)
+	if err != nil {
+		return nil, fmt.Errorf("secret key: %w", ErrInvalidHex)
+	}
+	defer zeroize(b)
+	if err := checkSecretKeyRange(b); err != nil {
+		return nil, err
+	}
+	return bls.SecretKeyFromBytes(b)
+}
+
+// checkSecretKeyRange checks that b is a 32-byte big-endian scalar with
+// 0 < sk < r, so out-of-range imports fail clearly instead of inside the backend.
+func checkSecretKeyRange(b []byte) error {
+	if len(b) != SecretKeyLength {
+		return fmt.Errorf("%w: got %d", ErrSecretKeyLength, len(b))
+	}
+	sk := new(big.Int).SetBytes(b)
+	defer sk.SetInt64(0)
+	if sk.Sign() == 0 || sk.Cmp(curveOrder) >= 0 {
+		return ErrSecretKeyOutOfRange
+	}
+	return nil
+}
+
+func zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func publicKeyFromHex(pubKeyHex string) (common.PublicKey, error) {
+	b, err := decodeHex(pubKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	if err := checkPubKeyLength(b); err != nil {
+		return nil, err
+	}
+	return bls.PublicKeyFromBytes(b)
+}
+
+// checkPubKeyLength rejects a compressed public key of the wrong size with an
+// error giving the size found.
+func checkPubKeyLength(b []byte) error {
+	if len(b) != PubKeyLength {
+		return fmt.Errorf("%w: public key is %d bytes, want %d", ErrInvalidPublicKey, len(b), PubKeyLength)
+	}
+	return nil
+}
+
+// canonicalPubKeyBytes validates a compressed (48-byte) or uncompressed
+// (96-byte) G1 public key and returns its compressed form.
+func canonicalPubKeyBytes(b []byte) ([]byte, error) {
+	if len(b) == blst.BLST_P1_SERIALIZE_BYTES {
+		p := new(blst.P1Affine).Deserialize(b)
+		if p == nil || !p.KeyValidate() {
+			return nil, common.ErrInfinitePubKey
+		}
+		return p.Compress(), nil
+	}
+	pk, err := bls.PublicKeyFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return pk.Marshal(), nil
+}
+
+// CanonicalizePubKeys decodes each public key, re-encodes it as compressed
+// 0x-prefixed hex, drops duplicates and returns the result sorted. If any entry
+// is invalid, the error lists their indices.
+func CanonicalizePubKeys(pubKeyHexes []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(pubKeyHexes))
+	out := make([]string, 0, len(pubKeyHexes))
+	var invalid []int
+	for i, h := range pubKeyHexes {
+		b, err := decodeHex(h)
+		if err == nil {
+			b, err = canonicalPubKeyBytes(b)
+		}
+		if err != nil {
+			invalid = append(invalid, i)
+			continue
+		}
+		c := EncodingEthereum.Encode(b)
+		if _, dup := seen[c]; dup {
+			continue
+		}
+		seen[c] = struct{}{}
+		out = append(out, c)
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("%w: entries %v", ErrInvalidPublicKeys, invalid)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// exampleIKM seeds ExampleKeyPair. It is published here, so the key is public.
+var exampleIKM = []byte("bls-sig example key: TEST ONLY!!")
+
+// ExampleKeyPair returns a fixed key pair for examples and the demo so their
+// output is reproducible.
+//
+// WARNING: the secret key is public. Never use it outside tests and examples.
+func ExampleKeyPair() (string, string) {
+	sk := blst.KeyGen(exampleIKM)
+	pk := new(blst.P1Affine).From(sk)
+	return EncodingEthereum.Encode(sk.Serialize()), EncodingEthereum.Encode(pk.Compress())
+}
+
+var ErrInvalidShardCount = errors.New("number of shards must be positive")
+
+// ShardIndex maps a public key to a shard in [0, numShards) by hashing its
+// canonical compressed encoding, so the assignment is stable across hex styles.
+func ShardIndex(pubKeyHex string, numShards int) (int, error) {
+	if numShards <= 0 {
+		return 0, ErrInvalidShardCount
+	}
+	b, err := decodeHex(pubKeyHex)
+	if err != nil {
+		return 0, fmt.Errorf("public key: %w", err)
+	}
+	if b, err = canonicalPubKeyBytes(b); err != nil {
+		return 0, err
+	}
+	h := sha256.Sum256(b)
+	return int(binary.BigEndian.Uint64(h[:8]) % uint64(numShards)), nil
+}
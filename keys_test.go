@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+func testKeyPair(t *testing.T) (skHex, pubKeyHex string) {
+	t.Helper()
+	skHex, pubKeyHex, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return skHex, pubKeyHex
+}
+
+func mustPubKey(t *testing.T, skHex string) string {
+	t.Helper()
+	sk, err := secretKeyFromHex(skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return EncodingEthereum.Encode(sk.PublicKey().Marshal())
+}
+
+func mustSign(t *testing.T, skHex, msg string) string {
+	t.Helper()
+	sig, err := GenerateSignature(skHex, []byte(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestGenerateKeyPairNISTEncodingRoundTrips(t *testing.T) {
+	skHex, pubKeyHex, err := GenerateKeyPairWithEncoding(EncodingNIST)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []string{skHex, pubKeyHex} {
+		if strings.HasPrefix(h, "0x") || h != strings.ToUpper(h) {
+			t.Fatalf("%q is not NIST-style hex", h)
+		}
+	}
+	sig, err := GenerateSignature(skHex, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := VerifySignature(pubKeyHex, sig, "hello")
+	if err != nil || !ok {
+		t.Fatalf("VerifySignature = %v, %v; want true", ok, err)
+	}
+	pk, err := publicKeyFromHex(pubKeyHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EncodingNIST.Encode(pk.Marshal()); got != pubKeyHex {
+		t.Fatalf("re-encoded public key %s, want %s", got, pubKeyHex)
+	}
+}
+
+func TestCanonicalizePubKeys(t *testing.T) {
+	_, a := testKeyPair(t)
+	_, b := testKeyPair(t)
+	ab, err := decodeHex(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aUncompressed := EncodingEthereum.Encode(new(blst.P1Affine).Uncompress(ab).Serialize())
+	aNIST := EncodingNIST.Encode(ab)
+
+	got, err := CanonicalizePubKeys([]string{b, aNIST, a, aUncompressed, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{a, b}
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CanonicalizePubKeys = %v, want %v", got, want)
+	}
+	again, err := CanonicalizePubKeys([]string{a, b})
+	if err != nil || !reflect.DeepEqual(again, got) {
+		t.Fatalf("order-dependent result: %v vs %v (%v)", again, got, err)
+	}
+
+	_, err = CanonicalizePubKeys([]string{a, "0x1234", b, "zz"})
+	if !errors.Is(err, ErrInvalidPublicKeys) || !strings.Contains(err.Error(), "[1 3]") {
+		t.Fatalf("invalid entries: err = %v, want ErrInvalidPublicKeys listing [1 3]", err)
+	}
+}
+
+func TestExampleKeyPairIsStable(t *testing.T) {
+	sk1, pk1 := ExampleKeyPair()
+	sk2, pk2 := ExampleKeyPair()
+	if sk1 != sk2 || pk1 != pk2 {
+		t.Fatal("ExampleKeyPair changed between calls")
+	}
+	sig1 := mustSign(t, sk1, "demo")
+	if sig2 := mustSign(t, sk2, "demo"); sig1 != sig2 {
+		t.Fatal("example key signatures differ between calls")
+	}
+}
+
+func TestShardIndexStableAndSpread(t *testing.T) {
+	const shards, keys = 8, 400
+	counts := make([]int, shards)
+	for i := 0; i < keys; i++ {
+		_, pk := testKeyPair(t)
+		idx, err := ShardIndex(pk, shards)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := ShardIndex(strings.ToUpper(strings.TrimPrefix(pk, "0x")), shards)
+		if err != nil || again != idx {
+			t.Fatalf("ShardIndex not stable across hex styles: %d then %d, %v", idx, again, err)
+		}
+		counts[idx]++
+	}
+	// Each shard expects 50 keys; 20 and 80 are far outside chance.
+	for s, c := range counts {
+		if c < 20 || c > 80 {
+			t.Fatalf("shard %d got %d of %d keys: %v", s, c, keys, counts)
+		}
+	}
+	_, pk := ExampleKeyPair()
+	if _, err := ShardIndex(pk, 0); !errors.Is(err, ErrInvalidShardCount) {
+		t.Fatalf("zero shards: err = %v, want ErrInvalidShardCount", err)
+	}
+}
+
+func TestSecretKeyRange(t *testing.T) {
+	r := curveOrder.FillBytes(make([]byte, 32))
+	rMinus1 := new(big.Int).Sub(curveOrder, big.NewInt(1)).FillBytes(make([]byte, 32))
+	tests := []struct {
+		name string
+		sk   []byte
+		err  error
+	}{
+		{"zero", make([]byte, 32), ErrSecretKeyOutOfRange},
+		{"r", r, ErrSecretKeyOutOfRange},
+		{"r-1", rMinus1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := secretKeyFromHex(EncodingEthereum.Encode(tt.sk))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("secretKeyFromHex: err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,528 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// EIP-2335 keystore support.
+
+const keystoreVersion = 4
+
+// KeyGenVersion tags keystores for keys generated by GenerateAndBackup, so
+// that keys from a release with a key-generation bug can be refused later.
+// Bump it whenever key generation changes. Imported keys and keystores
+// without a tag count as version 0. The tag sits outside the EIP-2335
+// checksum, which covers only the ciphertext, so anyone who can edit the file
+// can raise it: a minimum version guards against our own old keys, not
+// against a tampered keystore.
+const KeyGenVersion = 1
+
+// scrypt parameters from the EIP-2335 reference keystore.
+const (
+	keystoreScryptN     = 1 << 18
+	keystoreScryptR     = 8
+	keystoreScryptP     = 1
+	keystoreDKLen       = 32
+	keystoreSaltLen     = 32
+	keystoreCipherAES   = "aes-128-ctr"
+	keystoreChecksumSHA = "sha256"
+)
+
+var (
+	ErrWrongPassword      = errors.New("keystore checksum mismatch: wrong password")
+	ErrUnsupportedKDF     = errors.New("unsupported keystore KDF")
+	ErrUnsupportedCipher  = errors.New("unsupported keystore cipher")
+	ErrKeystoreVersion    = errors.New("unsupported keystore version")
+	ErrKeystorePubKeyDiff = errors.New("keystore pubkey does not match decrypted secret key")
+	ErrKeyTooOld          = errors.New("key was generated by a library version below the minimum")
+	ErrNotKeystore        = errors.New("not an EIP-2335 keystore")
+)
+
+type keystore struct {
+	Crypto      keystoreCrypto `json:"crypto"`
+	Description string         `json:"description"`
+	PubKey      string         `json:"pubkey"`
+	Path        string         `json:"path"`
+	UUID        string         `json:"uuid"`
+	Version     int            `json:"version"`
+	// KeyGenVersion is not part of EIP-2335 or its checksum; other tools
+	// ignore it.
+	KeyGenVersion int `json:"keygen_version,omitempty"`
+}
+
+type keystoreCrypto struct {
+	KDF      keystoreKDF      `json:"kdf"`
+	Checksum keystoreChecksum `json:"checksum"`
+	Cipher   keystoreCipher   `json:"cipher"`
+}
+
+type keystoreKDF struct {
+	Function string    `json:"function"`
+	Params   kdfParams `json:"params"`
+	Message  string    `json:"message"`
+}
+
+// kdfParams holds the parameters of either supported KDF: scrypt uses N, R
+// and P; pbkdf2 uses C and PRF.
+type kdfParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n,omitempty"`
+	R     int    `json:"r,omitempty"`
+	P     int    `json:"p,omitempty"`
+	C     int    `json:"c,omitempty"`
+	PRF   string `json:"prf,omitempty"`
+	Salt  string `json:"salt"`
+}
+
+type keystoreChecksum struct {
+	Function string   `json:"function"`
+	Params   struct{} `json:"params"`
+	Message  string   `json:"message"`
+}
+
+type keystoreCipher struct {
+	Function string `json:"function"`
+	Params   struct {
+		IV string `json:"iv"`
+	} `json:"params"`
+	Message string `json:"message"`
+}
+
+// keystorePassword applies the EIP-2335 password processing: NFKD
+// normalisation, then removal of C0, C1 and DEL control codes.
+func keystorePassword(password string) []byte {
+	return []byte(strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(password)))
+}
+
+func (k *keystoreKDF) deriveKey(password string) ([]byte, error) {
+	salt, err := decodeHex(k.Params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("kdf salt: %w", err)
+	}
+	pw := keystorePassword(password)
+	defer zeroize(pw)
+	p := k.Params
+	switch k.Function {
+	case "scrypt":
+		return scrypt.Key(pw, salt, p.N, p.R, p.P, p.DKLen)
+	case "pbkdf2":
+		if p.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("%w: pbkdf2 prf %q", ErrUnsupportedKDF, p.PRF)
+		}
+		return pbkdf2.Key(pw, salt, p.C, p.DKLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedKDF, k.Function)
+	}
+}
+
+func keystoreChecksumOf(dk, cipherText []byte) []byte {
+	h := sha256.New()
+	h.Write(dk[16:32])
+	h.Write(cipherText)
+	return h.Sum(nil)
+}
+
+func aes128CTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func newUUID() (string, error) {
+	b, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// EncryptKeystore encrypts the secret key skHex into an EIP-2335 keystore
+// using scrypt and AES-128-CTR. The key was not generated here, so the
+// keystore carries no KeyGenVersion tag.
+func EncryptKeystore(skHex, password string) ([]byte, error) {
+	return encryptKeystoreHex(skHex, password, keystore{})
+}
+
+func encryptKeystoreHex(skHex, password string, meta keystore) ([]byte, error) {
+	sk, err := secretKeyFromHex(skHex)
+	if err != nil {
+		return nil, err
+	}
+	secret := sk.Marshal()
+	defer zeroize(secret)
+	return encryptKeystore(secret, password, meta)
+}
+
+// encryptKeystore encrypts secret with fresh salt and IV, carrying over the
+// description, path, UUID and key-generation version of meta. A UUID is
+// generated if meta has none.
+func encryptKeystore(secret []byte, password string, meta keystore) ([]byte, error) {
+	sk, err := bls.SecretKeyFromBytes(secret)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := randomBytes(keystoreSaltLen)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := randomBytes(aes.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	id := meta.UUID
+	if id == "" {
+		if id, err = newUUID(); err != nil {
+			return nil, err
+		}
+	}
+
+	ks := keystore{
+		Description:   meta.Description,
+		PubKey:        hex.EncodeToString(sk.PublicKey().Marshal()),
+		Path:          meta.Path,
+		UUID:          id,
+		Version:       keystoreVersion,
+		KeyGenVersion: meta.KeyGenVersion,
+	}
+	ks.Crypto.KDF = keystoreKDF{
+		Function: "scrypt",
+		Params: kdfParams{
+			DKLen: keystoreDKLen,
+			N:     keystoreScryptN,
+			R:     keystoreScryptR,
+			P:     keystoreScryptP,
+			Salt:  hex.EncodeToString(salt),
+		},
+	}
+	dk, err := ks.Crypto.KDF.deriveKey(password)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroize(dk)
+	cipherText, err := aes128CTR(dk[:16], iv, secret)
+	if err != nil {
+		return nil, err
+	}
+	ks.Crypto.Cipher.Function = keystoreCipherAES
+	ks.Crypto.Cipher.Params.IV = hex.EncodeToString(iv)
+	ks.Crypto.Cipher.Message = hex.EncodeToString(cipherText)
+	ks.Crypto.Checksum.Function = keystoreChecksumSHA
+	ks.Crypto.Checksum.Message = hex.EncodeToString(keystoreChecksumOf(dk, cipherText))
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// DecryptKeystore decrypts an EIP-2335 keystore and returns the secret key as
+// hex. A wrong password yields ErrWrongPassword.
+func DecryptKeystore(keystoreJSON []byte, password string) (string, error) {
+	secret, _, err := decryptKeystore(keystoreJSON, password)
+	if err != nil {
+		return "", err
+	}
+	defer zeroize(secret)
+	return EncodingEthereum.Encode(secret), nil
+}
+
+// DecryptKeystoreMinVersion is DecryptKeystore but first returns ErrKeyTooOld
+// if the keystore's key-generation version is below minVersion.
+func DecryptKeystoreMinVersion(keystoreJSON []byte, password string, minVersion int) (string, error) {
+	if _, err := checkKeystoreVersion(keystoreJSON, minVersion); err != nil {
+		return "", err
+	}
+	return DecryptKeystore(keystoreJSON, password)
+}
+
+// VerifyWithKeystore verifies sigHex over msg against the public key recorded
+// in keystoreJSON, returning ErrKeyTooOld if the key's generation version is
+// below minVersion. No password is needed.
+func VerifyWithKeystore(keystoreJSON []byte, sigHex, msg string, minVersion int) (bool, error) {
+	ks, err := checkKeystoreVersion(keystoreJSON, minVersion)
+	if err != nil {
+		return false, err
+	}
+	return VerifySignature(ks.PubKey, sigHex, msg)
+}
+
+func checkKeystoreVersion(keystoreJSON []byte, minVersion int) (keystore, error) {
+	var ks keystore
+	if err := json.Unmarshal(keystoreJSON, &ks); err != nil {
+		return ks, fmt.Errorf("parse keystore: %w", err)
+	}
+	if ks.KeyGenVersion < minVersion {
+		return ks, fmt.Errorf("%w: version %d, minimum %d", ErrKeyTooOld, ks.KeyGenVersion, minVersion)
+	}
+	return ks, nil
+}
+
+// decryptKeystore returns the raw secret key, which the caller must zeroize,
+// along with the parsed keystore.
+func decryptKeystore(keystoreJSON []byte, password string) ([]byte, keystore, error) {
+	var ks keystore
+	if err := json.Unmarshal(keystoreJSON, &ks); err != nil {
+		return nil, ks, fmt.Errorf("parse keystore: %w", err)
+	}
+	if ks.Version != keystoreVersion {
+		return nil, ks, fmt.Errorf("%w: %d", ErrKeystoreVersion, ks.Version)
+	}
+	if ks.Crypto.Cipher.Function != keystoreCipherAES {
+		return nil, ks, fmt.Errorf("%w: %q", ErrUnsupportedCipher, ks.Crypto.Cipher.Function)
+	}
+	if ks.Crypto.KDF.Params.DKLen < keystoreDKLen {
+		return nil, ks, fmt.Errorf("%w: dklen %d", ErrUnsupportedKDF, ks.Crypto.KDF.Params.DKLen)
+	}
+	cipherText, err := decodeHex(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, ks, fmt.Errorf("cipher message: %w", err)
+	}
+	iv, err := decodeHex(ks.Crypto.Cipher.Params.IV)
+	if err != nil {
+		return nil, ks, fmt.Errorf("cipher iv: %w", err)
+	}
+	// The checksum doesn't cover the IV, so a bad one must be caught here
+	// rather than left to panic in cipher.NewCTR.
+	if len(iv) != aes.BlockSize {
+		return nil, ks, fmt.Errorf("%w: iv is %d bytes, want %d", ErrUnsupportedCipher, len(iv), aes.BlockSize)
+	}
+	checksum, err := decodeHex(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, ks, fmt.Errorf("checksum: %w", err)
+	}
+
+	dk, err := ks.Crypto.KDF.deriveKey(password)
+	if err != nil {
+		return nil, ks, err
+	}
+	defer zeroize(dk)
+	if !bytes.Equal(keystoreChecksumOf(dk, cipherText), checksum) {
+		return nil, ks, ErrWrongPassword
+	}
+	secret, err := aes128CTR(dk[:16], iv, cipherText)
+	if err != nil {
+		return nil, ks, err
+	}
+
+	if err := checkSecretKeyRange(secret); err != nil {
+		zeroize(secret)
+		return nil, ks, err
+	}
+	sk, err := bls.SecretKeyFromBytes(secret)
+	if err != nil {
+		zeroize(secret)
+		return nil, ks, err
+	}
+	if ks.PubKey != "" {
+		want, err := decodeHex(ks.PubKey)
+		if err != nil || !bytes.Equal(want, sk.PublicKey().Marshal()) {
+			zeroize(secret)
+			return nil, ks, ErrKeystorePubKeyDiff
+		}
+	}
+	return secret, ks, nil
+}
+
+// RekeyKeystore re-encrypts a keystore under newPassword with a fresh salt and
+// IV, keeping its public key, path, description and UUID.
+func RekeyKeystore(keystoreJSON []byte, oldPassword, newPassword string) ([]byte, error) {
+	secret, ks, err := decryptKeystore(keystoreJSON, oldPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroize(secret)
+	return encryptKeystore(secret, newPassword, ks)
+}
+
+// SignAll decrypts every .json keystore in keystoreDir with password and signs
+// msg with each, returning signatures keyed by public key hex. A keystore that
+// fails to load is reported in the joined error without stopping the others.
+func SignAll(keystoreDir, password string, msg []byte) (map[string]string, error) {
+	entries, err := os.ReadDir(keystoreDir)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make(map[string]string)
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		pubKeyHex, sigHex, err := signWithKeystoreFile(filepath.Join(keystoreDir, e.Name()), password, msg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name(), err))
+			continue
+		}
+		sigs[pubKeyHex] = sigHex
+	}
+	return sigs, errors.Join(errs...)
+}
+
+func signWithKeystoreFile(path, password string, msg []byte) (pubKeyHex, sigHex string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	skHex, err := DecryptKeystore(data, password)
+	if err != nil {
+		return "", "", err
+	}
+	signer, err := NewLocalSigner(skHex)
+	if err != nil {
+		return "", "", err
+	}
+	pubKeyHex, err = signer.PublicKey()
+	if err != nil {
+		return "", "", err
+	}
+	sigHex, err = signer.Sign(msg)
+	if err != nil {
+		return "", "", err
+	}
+	return pubKeyHex, sigHex, nil
+}
+
+// GenerateAndBackup generates a key pair, encrypts the secret key into an
+// EIP-2335 keystore at path and returns the public key only once the file is
+// durably on disk. The secret key itself is never returned. The keystore is
+// tagged with KeyGenVersion.
+func GenerateAndBackup(password, path string) (pubKeyHex string, err error) {
+	skHex, pubKeyHex, err := GenerateKeyPair()
+	if err != nil {
+		return "", err
+	}
+	data, err := encryptKeystoreHex(skHex, password, keystore{KeyGenVersion: KeyGenVersion})
+	if err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return pubKeyHex, nil
+}
+
+// writeFileAtomic writes data to a temporary file beside path, syncs it and
+// renames it into place, so readers see either nothing or the whole file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer func() {
+		if err != nil {
+			os.Remove(tmp)
+		}
+	}()
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return err
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// KeystoreInfo is the public metadata of a keystore file.
+type KeystoreInfo struct {
+	File          string `json:"file"`
+	PubKey        string `json:"pubkey"`
+	KDF           string `json:"kdf"`
+	Cipher        string `json:"cipher"`
+	Version       int    `json:"version"`
+	Path          string `json:"path,omitempty"`
+	UUID          string `json:"uuid,omitempty"`
+	KeyGenVersion int    `json:"keygen_version,omitempty"`
+}
+
+// InspectKeystoreDir lists the public metadata of every .json keystore in dir
+// without needing a password. A file counts as a keystore only if it sets a
+// version, a KDF function and a cipher function; anything else is reported
+// in the joined error and skipped.
+func InspectKeystoreDir(dir string) ([]KeystoreInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var infos []KeystoreInfo
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var ks keystore
+		if err := json.Unmarshal(data, &ks); err != nil {
+			errs = append(errs, fmt.Errorf("%s: parse keystore: %w", e.Name(), err))
+			continue
+		}
+		if ks.Version == 0 || ks.Crypto.KDF.Function == "" || ks.Crypto.Cipher.Function == "" {
+			errs = append(errs, fmt.Errorf("%s: %w: missing version, kdf or cipher", e.Name(), ErrNotKeystore))
+			continue
+		}
+		infos = append(infos, KeystoreInfo{
+			File:          e.Name(),
+			PubKey:        ks.PubKey,
+			KDF:           ks.Crypto.KDF.Function,
+			Cipher:        ks.Crypto.Cipher.Function,
+			Version:       ks.Version,
+			Path:          ks.Path,
+			UUID:          ks.UUID,
+			KeyGenVersion: ks.KeyGenVersion,
+		})
+	}
+	return infos, errors.Join(errs...)
+}
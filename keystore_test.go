@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestKeystore encrypts skHex under password into dir/name.
+func writeTestKeystore(t *testing.T, dir, name, skHex, password string) {
+	t.Helper()
+	data, err := EncryptKeystore(skHex, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignAll(t *testing.T) {
+	dir := t.TempDir()
+	sk1, pk1 := testKeyPair(t)
+	sk2, pk2 := testKeyPair(t)
+	writeTestKeystore(t, dir, "a.json", sk1, "hunter2")
+	writeTestKeystore(t, dir, "b.json", sk2, "hunter2")
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sigs, err := SignAll(dir, "hunter2", []byte("epoch 7"))
+	if err == nil {
+		t.Fatal("broken keystore was not reported")
+	}
+	if len(sigs) != 2 {
+		t.Fatalf("got %d signatures, want 2", len(sigs))
+	}
+	for _, pk := range []string{pk1, pk2} {
+		sig, ok := sigs[pk]
+		if !ok {
+			t.Fatalf("no signature for %s", pk)
+		}
+		if ok, err := VerifySignature(pk, sig, "epoch 7"); err != nil || !ok {
+			t.Fatalf("signature for %s: %v, %v; want valid", pk, ok, err)
+		}
+	}
+}
+
+func TestGenerateAndBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "validator.json")
+	pubKeyHex, err := GenerateAndBackup("hunter2", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skHex, err := DecryptKeystore(data, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := mustSign(t, skHex, "first duty")
+	if ok, err := VerifySignature(pubKeyHex, sig, "first duty"); err != nil || !ok {
+		t.Fatalf("backed-up key does not sign for %s: %v, %v", pubKeyHex, ok, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("directory holds %d files, want only the keystore", len(entries))
+	}
+}
+
+func TestInspectKeystoreDir(t *testing.T) {
+	dir := t.TempDir()
+	sk, pk := testKeyPair(t)
+	writeTestKeystore(t, dir, "scrypt.json", sk, "hunter2")
+
+	// A pbkdf2 keystore: same layout, different KDF block.
+	data, err := os.ReadFile(filepath.Join(dir, "scrypt.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ks keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		t.Fatal(err)
+	}
+	ks.Crypto.KDF.Function = "pbkdf2"
+	ks.Crypto.KDF.Params = kdfParams{DKLen: 32, C: 262144, PRF: "hmac-sha256", Salt: ks.Crypto.KDF.Params.Salt}
+	ks.Path = "m/12381/3600/0/0/0"
+	pbkdf2JSON, err := json.Marshal(ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pbkdf2.json"), pbkdf2JSON, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.json"), []byte("not a keystore"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Valid JSON that decodes into a keystore but isn't one.
+	if err := os.WriteFile(filepath.Join(dir, "empty.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"path": "/var/lib/node", "description": "node config"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err := InspectKeystoreDir(dir)
+	if err == nil {
+		t.Fatal("notes.json was not reported")
+	}
+	if !errors.Is(err, ErrNotKeystore) || !strings.Contains(err.Error(), "empty.json") || !strings.Contains(err.Error(), "config.json") {
+		t.Fatalf("non-keystore JSON not reported: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("got %d keystores, want 2", len(infos))
+	}
+	want := map[string]string{"pbkdf2.json": "pbkdf2", "scrypt.json": "scrypt"}
+	for _, info := range infos {
+		if info.KDF != want[info.File] {
+			t.Errorf("%s: KDF %q, want %q", info.File, info.KDF, want[info.File])
+		}
+		if info.PubKey != strings.TrimPrefix(pk, "0x") || info.Cipher != keystoreCipherAES || info.Version != keystoreVersion {
+			t.Errorf("%s: got %+v", info.File, info)
+		}
+	}
+}
+
+func TestRekeyKeystore(t *testing.T) {
+	sk, _ := testKeyPair(t)
+	old, err := EncryptKeystore(sk, "old password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rekeyed, err := RekeyKeystore(old, "old password", "new password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptKeystore(rekeyed, "old password"); !errors.Is(err, ErrWrongPassword) {
+		t.Fatalf("old password: err = %v, want ErrWrongPassword", err)
+	}
+	got, err := DecryptKeystore(rekeyed, "new password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != sk {
+		t.Fatal("rekeyed keystore holds a different secret key")
+	}
+
+	var a, b keystore
+	if err := json.Unmarshal(old, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(rekeyed, &b); err != nil {
+		t.Fatal(err)
+	}
+	if a.PubKey != b.PubKey || a.UUID != b.UUID {
+		t.Fatal("rekeying changed the public key or UUID")
+	}
+	if a.Crypto.KDF.Params.Salt == b.Crypto.KDF.Params.Salt || a.Crypto.Cipher.Params.IV == b.Crypto.Cipher.Params.IV {
+		t.Fatal("rekeying reused the salt or IV")
+	}
+}
+
+func TestKeyGenVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generated.json")
+	pubKeyHex, err := GenerateAndBackup("hunter2", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skHex, err := DecryptKeystoreMinVersion(generated, "hunter2", KeyGenVersion)
+	if err != nil {
+		t.Fatalf("generated key at the current version: %v", err)
+	}
+	sig := mustSign(t, skHex, "duty")
+	if ok, err := VerifyWithKeystore(generated, sig, "duty", KeyGenVersion); err != nil || !ok {
+		t.Fatalf("VerifyWithKeystore: ok=%v err=%v", ok, err)
+	}
+
+	// A key tagged by an older release is refused once the minimum moves past it.
+	if _, err := DecryptKeystoreMinVersion(generated, "hunter2", KeyGenVersion+1); !errors.Is(err, ErrKeyTooOld) {
+		t.Fatalf("old-tagged key: err = %v, want ErrKeyTooOld", err)
+	}
+	if _, err := VerifyWithKeystore(generated, sig, "duty", KeyGenVersion+1); !errors.Is(err, ErrKeyTooOld) {
+		t.Fatalf("old-tagged key: VerifyWithKeystore err = %v, want ErrKeyTooOld", err)
+	}
+
+	// An imported key was not generated here and carries no tag.
+	imported, err := EncryptKeystore(skHex, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ks keystore
+	if err := json.Unmarshal(imported, &ks); err != nil {
+		t.Fatal(err)
+	}
+	if ks.KeyGenVersion != 0 || ks.PubKey != strings.TrimPrefix(pubKeyHex, "0x") {
+		t.Fatalf("imported keystore: keygen_version %d, pubkey %s", ks.KeyGenVersion, ks.PubKey)
+	}
+	if _, err := VerifyWithKeystore(imported, sig, "duty", 1); !errors.Is(err, ErrKeyTooOld) {
+		t.Fatalf("imported key: err = %v, want ErrKeyTooOld", err)
+	}
+}
+
+func TestDecryptKeystoreShortIV(t *testing.T) {
+	sk, _ := testKeyPair(t)
+	data, err := EncryptKeystore(sk, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ks keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		t.Fatal(err)
+	}
+	ks.Crypto.Cipher.Params.IV = ks.Crypto.Cipher.Params.IV[:16]
+	if data, err = json.Marshal(ks); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptKeystore(data, "hunter2"); !errors.Is(err, ErrUnsupportedCipher) {
+		t.Fatalf("8-byte IV: err = %v, want ErrUnsupportedCipher", err)
+	}
+	if _, err := RekeyKeystore(data, "hunter2", "new"); !errors.Is(err, ErrUnsupportedCipher) {
+		t.Fatalf("RekeyKeystore with 8-byte IV: err = %v, want ErrUnsupportedCipher", err)
+	}
+}
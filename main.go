@@ -1,7 +1,10 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
+	"os"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
@@ -9,6 +12,28 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "convert" {
+		if err := runConvert(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	output := flag.String("output", "", `bulk mode: "csv" reads a JSON array of {pubkey, signature, message} from stdin and streams results as CSV`)
+	flag.Parse()
+	switch *output {
+	case "":
+	case "csv":
+		if err := runCSV(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output mode %q\n", *output)
+		os.Exit(2)
+	}
 
 	var (
 		xMsgs      [][32]byte
@@ -17,7 +42,8 @@ func main() {
 		xPKs       []common.PublicKey
 	)
 
-	sk, err := bls.RandKey()
+	skHex, _ := ExampleKeyPair()
+	sk, err := secretKeyFromHex(skHex)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -61,3 +87,11 @@ func main() {
 	}
 	fmt.Println("Verification result for multiple sig:", s)
 }
+
+func runCSV() error {
+	var entries []VerifyTuple
+	if err := json.NewDecoder(os.Stdin).Decode(&entries); err != nil {
+		return fmt.Errorf("read entries: %w", err)
+	}
+	return VerifyStreamCSV(entries, os.Stdout)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// merkleSignDomain separates signatures over a Merkle root from signatures
+// over an ordinary 32-byte message.
+var merkleSignDomain = []byte("bls-sig merkle root")
+
+var (
+	ErrNoMessages       = errors.New("no messages")
+	ErrInvalidAggregate = errors.New("aggregate signature does not verify")
+	ErrMerkleRootLength = errors.New("merkle root must be 32 bytes")
+)
+
+// merkleRoot hashes messages into a binary Merkle tree with RFC 6962 leaf and
+// node prefixes, so a leaf can never be confused with an inner node. An odd
+// node at the end of a level is carried up unchanged.
+func merkleRoot(messages [][]byte) [32]byte {
+	level := make([][32]byte, len(messages))
+	for i, m := range messages {
+		level[i] = sha256.Sum256(append([]byte{0x00}, m...))
+	}
+	for len(level) > 1 {
+		next := level[:0:0]
+		for i := 0; i+1 < len(level); i += 2 {
+			node := make([]byte, 0, 65)
+			node = append(node, 0x01)
+			node = append(node, level[i][:]...)
+			node = append(node, level[i+1][:]...)
+			next = append(next, sha256.Sum256(node))
+		}
+		if len(level)%2 == 1 {
+			next = append(next, level[len(level)-1])
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// MerkleRoot returns the hex Merkle root of messages that signers sign for
+// AggregateOverMerkleRoot.
+func MerkleRoot(messages [][]byte) (string, error) {
+	if len(messages) == 0 {
+		return "", ErrNoMessages
+	}
+	root := merkleRoot(messages)
+	return EncodingEthereum.Encode(root[:]), nil
+}
+
+func merkleSigningRoot(root []byte) [32]byte {
+	return BuildSigningInput(merkleSignDomain, root)
+}
+
+// SignMerkleRoot signs the Merkle root of messages under a domain of its own.
+func SignMerkleRoot(skHex string, messages [][]byte) (string, error) {
+	if len(messages) == 0 {
+		return "", ErrNoMessages
+	}
+	root := merkleRoot(messages)
+	return signRoot(skHex, merkleSigningRoot(root[:]))
+}
+
+// AggregateOverMerkleRoot aggregates signatures that pubKeyHexes each made with
+// SignMerkleRoot over messages, checks the aggregate against that root and
+// returns both. One signature covers the whole message set.
+func AggregateOverMerkleRoot(sigHexes []string, messages [][]byte, pubKeyHexes []string) (aggSigHex, rootHex string, err error) {
+	if len(sigHexes) != len(pubKeyHexes) {
+		return "", "", fmt.Errorf("%w: %d signatures, %d public keys", ErrLengthMismatch, len(sigHexes), len(pubKeyHexes))
+	}
+	if rootHex, err = MerkleRoot(messages); err != nil {
+		return "", "", err
+	}
+	if aggSigHex, err = AggregateSignatures(sigHexes); err != nil {
+		return "", "", err
+	}
+	ok, err := VerifyMerkleAggregate(aggSigHex, rootHex, pubKeyHexes)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok {
+		return "", "", ErrInvalidAggregate
+	}
+	return aggSigHex, rootHex, nil
+}
+
+// VerifyMerkleAggregate checks an aggregate from AggregateOverMerkleRoot
+// against the Merkle root and its signers.
+func VerifyMerkleAggregate(aggSigHex, rootHex string, pubKeyHexes []string) (bool, error) {
+	root, err := decodeHex(rootHex)
+	if err != nil {
+		return false, fmt.Errorf("merkle root: %w", err)
+	}
+	if len(root) != 32 {
+		return false, ErrMerkleRootLength
+	}
+	return fastAggregateVerifyRoot(aggSigHex, merkleSigningRoot(root), pubKeyHexes)
+}
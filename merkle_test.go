@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAggregateOverMerkleRoot(t *testing.T) {
+	messages := [][]byte{[]byte("tx0"), []byte("tx1"), []byte("tx2"), []byte("tx3")}
+	var pks, sigs []string
+	for i := 0; i < 3; i++ {
+		sk, pk := testKeyPair(t)
+		sig, err := SignMerkleRoot(sk, messages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pks = append(pks, pk)
+		sigs = append(sigs, sig)
+	}
+	agg, rootHex, err := AggregateOverMerkleRoot(sigs, messages, pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := MerkleRoot(messages); rootHex != want {
+		t.Fatalf("root %s, want %s", rootHex, want)
+	}
+	if ok, err := VerifyMerkleAggregate(agg, rootHex, pks); err != nil || !ok {
+		t.Fatalf("VerifyMerkleAggregate = %v, %v; want true", ok, err)
+	}
+
+	reordered := [][]byte{messages[1], messages[0], messages[2], messages[3]}
+	if _, _, err := AggregateOverMerkleRoot(sigs, reordered, pks); !errors.Is(err, ErrInvalidAggregate) {
+		t.Fatalf("reordered messages: err = %v, want ErrInvalidAggregate", err)
+	}
+
+	// A plain signature over the root bytes is not a Merkle root signature.
+	root, err := decodeHex(rootHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk, pk := testKeyPair(t)
+	plain, err := GenerateSignature(sk, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyMerkleAggregate(plain, rootHex, []string{pk}); err != nil || ok {
+		t.Fatalf("plain signature over the root: %v, %v; want false", ok, err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// MsgCtx caches a message's hash-to-curve point for verifying many
+// signatures over that message against different keys. That point is the
+// only message-side work it reuses: both Miller loops and the final
+// exponentiation depend on the key or signature and run on every Verify, and
+// the blst bindings offer no precomputed pairing lines for the message point.
+// Verifying 1000 signatures on one core took about 1.47ms each against
+// 1.63ms for VerifySignature (BenchmarkMsgCtx), so the saving is about 10%.
+type MsgCtx struct {
+	h *blst.P2Affine
+	g *blst.P1Affine
+}
+
+// MessageContext hashes msg to G2 once, exactly as VerifySignature does.
+func MessageContext(msg []byte) *MsgCtx {
+	root := signingRoot(msg)
+	return &MsgCtx{
+		h: blst.HashToG2(root[:], schemeDSTs[SchemePOP]).ToAffine(),
+		g: blst.P1Generator().ToAffine(),
+	}
+}
+
+// Verify checks sigHex over the context's message against pubKeyHex, testing
+// e(pk, H(m)) = e(G1, sig).
+func (c *MsgCtx) Verify(pubKeyHex, sigHex string) (bool, error) {
+	pk, err := blstPublicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return false, fmt.Errorf("signature: %w", err)
+	}
+	sig, err := decodeSignaturePoint(b)
+	if err != nil {
+		return false, err
+	}
+	return blst.Fp12FinalVerify(blst.Fp12MillerLoop(c.h, pk), blst.Fp12MillerLoop(sig, c.g)), nil
+}
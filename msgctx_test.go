@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestMsgCtxMatchesVerifySignature(t *testing.T) {
+	const msg = "block 7"
+	sks, pks, sigs := testSigners(t, 3, msg)
+	ctx := MessageContext([]byte(msg))
+	for i := range pks {
+		if ok, err := ctx.Verify(pks[i], sigs[i]); err != nil || !ok {
+			t.Fatalf("signer %d: ok=%v err=%v", i, ok, err)
+		}
+	}
+	// Wrong key and a signature over another message must both fail.
+	if ok, err := ctx.Verify(pks[1], sigs[0]); err != nil || ok {
+		t.Fatalf("wrong key: ok=%v err=%v", ok, err)
+	}
+	if ok, err := ctx.Verify(pks[0], mustSign(t, sks[0], "block 8")); err != nil || ok {
+		t.Fatalf("other message: ok=%v err=%v", ok, err)
+	}
+}
+
+func benchmarkSameMessageSignatures(b *testing.B, n int, msg string) (pks, sigs []string) {
+	b.Helper()
+	for i := 0; i < n; i++ {
+		sk, pk, err := GenerateKeyPair()
+		if err != nil {
+			b.Fatal(err)
+		}
+		sig, err := GenerateSignature(sk, []byte(msg))
+		if err != nil {
+			b.Fatal(err)
+		}
+		pks = append(pks, pk)
+		sigs = append(sigs, sig)
+	}
+	return pks, sigs
+}
+
+// Each iteration verifies 1000 signatures over one message.
+func BenchmarkMsgCtx(b *testing.B) {
+	const msg = "attestation"
+	pks, sigs := benchmarkSameMessageSignatures(b, 1000, msg)
+	b.Run("MsgCtx", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ctx := MessageContext([]byte(msg))
+			for j := range pks {
+				if ok, err := ctx.Verify(pks[j], sigs[j]); err != nil || !ok {
+					b.Fatalf("signer %d: ok=%v err=%v", j, ok, err)
+				}
+			}
+		}
+	})
+	b.Run("VerifySignature", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range pks {
+				if ok, err := VerifySignature(pks[j], sigs[j], msg); err != nil || !ok {
+					b.Fatalf("signer %d: ok=%v err=%v", j, ok, err)
+				}
+			}
+		}
+	})
+}
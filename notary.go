@@ -0,0 +1,27 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// notaryDomain separates notarizations from signatures over ordinary messages.
+var notaryDomain = []byte("bls-sig notarization")
+
+func notarizationRoot(msg []byte, timestamp int64) [32]byte {
+	h := sha256.Sum256(msg)
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(timestamp))
+	return BuildSigningInput(notaryDomain, h[:], ts[:])
+}
+
+// NotarizeMessage signs msg bound to timestamp, so the signature attests to
+// both the content and the time it was notarized.
+func NotarizeMessage(skHex string, msg []byte, timestamp int64) (sigHex string, err error) {
+	return signRoot(skHex, notarizationRoot(msg, timestamp))
+}
+
+// VerifyNotarization checks a NotarizeMessage signature for msg at timestamp.
+func VerifyNotarization(pubKeyHex, sigHex string, msg []byte, timestamp int64) (bool, error) {
+	return verifyRoot(pubKeyHex, sigHex, notarizationRoot(msg, timestamp))
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+)
+
+func TestNotarizationBindsTimestamp(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("deed of sale")
+	const ts = 1760400000
+	sig, err := NotarizeMessage(skHex, msg, ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyNotarization(pubKeyHex, sig, msg, ts); err != nil || !ok {
+		t.Fatalf("same timestamp: %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyNotarization(pubKeyHex, sig, msg, ts+1); err != nil || ok {
+		t.Fatalf("changed timestamp: %v, %v; want false", ok, err)
+	}
+}
+
+func TestNotarizationIsNotAADSignature(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("deed of sale")
+	const ts = 1760400000
+	h := sha256.Sum256(msg)
+	var tsBytes [8]byte
+	binary.BigEndian.PutUint64(tsBytes[:], ts)
+
+	sig, err := NotarizeMessage(skHex, msg, ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyWithAAD(pubKeyHex, sig, h[:], tsBytes[:]); err != nil || ok {
+		t.Fatalf("notarization accepted as AAD signature: %v, %v", ok, err)
+	}
+	aadSig, err := SignWithAAD(skHex, h[:], tsBytes[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyNotarization(pubKeyHex, aadSig, msg, ts); err != nil || ok {
+		t.Fatalf("AAD signature accepted as notarization: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrMessageTooLong = errors.New("message does not fit in padding block")
+
+// padMessage applies ISO/IEC 7816-4 padding: a 0x80 byte, then zeros up to
+// blockSize. The marker byte makes the padding reversible, so msg must be
+// shorter than blockSize.
+func padMessage(msg []byte, blockSize int) ([]byte, error) {
+	if len(msg) >= blockSize {
+		return nil, fmt.Errorf("%w: %d bytes, block size %d", ErrMessageTooLong, len(msg), blockSize)
+	}
+	padded := make([]byte, blockSize)
+	copy(padded, msg)
+	padded[len(msg)] = 0x80
+	return padded, nil
+}
+
+// SignPadded pads msg to blockSize before signing it, so the signed message
+// does not reveal its true length.
+func SignPadded(skHex string, msg []byte, blockSize int) (string, error) {
+	padded, err := padMessage(msg, blockSize)
+	if err != nil {
+		return "", err
+	}
+	return GenerateSignature(skHex, padded)
+}
+
+// VerifyPadded checks a signature made by SignPadded with the same blockSize.
+func VerifyPadded(pubKeyHex, sigHex string, msg []byte, blockSize int) (bool, error) {
+	padded, err := padMessage(msg, blockSize)
+	if err != nil {
+		return false, err
+	}
+	return VerifySignature(pubKeyHex, sigHex, string(padded))
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSignPaddedRoundTrip(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("yes")
+	sig, err := SignPadded(skHex, msg, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyPadded(pubKeyHex, sig, msg, 64); err != nil || !ok {
+		t.Fatalf("VerifyPadded = %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyPadded(pubKeyHex, sig, msg, 32); err != nil || ok {
+		t.Fatalf("other block size: %v, %v; want false", ok, err)
+	}
+	if ok, err := VerifyPadded(pubKeyHex, sig, append(msg, 0x80), 64); err != nil || ok {
+		t.Fatalf("message ending in the marker byte: %v, %v; want false", ok, err)
+	}
+	if _, err := SignPadded(skHex, make([]byte, 64), 64); !errors.Is(err, ErrMessageTooLong) {
+		t.Fatalf("64-byte message: err = %v, want ErrMessageTooLong", err)
+	}
+}
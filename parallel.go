@@ -0,0 +1,190 @@
+package main
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxVerifyConcurrency holds the limit set by SetMaxVerifyConcurrency.
+var maxVerifyConcurrency atomic.Int64
+
+// SetMaxVerifyConcurrency caps how many pairing checks VerifyParallel runs at
+// once across all callers. Values below 1 are treated as 1; the default is
+// GOMAXPROCS at start-up. It is safe to call while verifications are running.
+func SetMaxVerifyConcurrency(n int) {
+	maxVerifyConcurrency.Store(int64(max(n, 1)))
+	verifySlots.cond.Broadcast()
+}
+
+// MaxVerifyConcurrency returns the limit set by SetMaxVerifyConcurrency.
+func MaxVerifyConcurrency() int {
+	return int(maxVerifyConcurrency.Load())
+}
+
+// verifySlots is the counting semaphore behind MaxVerifyConcurrency.
+var verifySlots = struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	active int
+}{}
+
+func init() {
+	verifySlots.cond = sync.NewCond(&verifySlots.mu)
+	maxVerifyConcurrency.Store(int64(max(runtime.GOMAXPROCS(0), 1)))
+}
+
+func acquireVerifySlot() {
+	verifySlots.mu.Lock()
+	for verifySlots.active >= MaxVerifyConcurrency() {
+		verifySlots.cond.Wait()
+	}
+	verifySlots.active++
+	verifySlots.mu.Unlock()
+}
+
+func releaseVerifySlot() {
+	verifySlots.mu.Lock()
+	verifySlots.active--
+	verifySlots.mu.Unlock()
+	verifySlots.cond.Signal()
+}
+
+// VerifyParallel verifies each entry on its own, running up to
+// MaxVerifyConcurrency verifications at a time, and returns per-entry results.
+func VerifyParallel(entries []VerifyTuple) ([]bool, error) {
+	results := make([]bool, len(entries))
+	errs := make([]error, len(entries))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(MaxVerifyConcurrency(), len(entries)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				acquireVerifySlot()
+				e := entries[i]
+				results[i], errs[i] = VerifySignature(e.PubKey, e.Signature, e.Message)
+				releaseVerifySlot()
+			}
+		}()
+	}
+	for i := range entries {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, batchElementError(i, err)
+		}
+	}
+	return results, nil
+}
+
+// parallelVerifyThreshold holds the setting of SetParallelVerifyThreshold.
+var parallelVerifyThreshold atomic.Int64
+
+// SetParallelVerifyThreshold sets the batch size from which VerifyAuto
+// switches from serial to parallel verification. Zero, the default, means use
+// the threshold measured by CalibrateParallelThreshold on first use; set it to
+// skip calibration. Negative values are treated as zero.
+func SetParallelVerifyThreshold(n int) {
+	parallelVerifyThreshold.Store(int64(max(n, 0)))
+}
+
+// ParallelVerifyThreshold returns the setting of SetParallelVerifyThreshold.
+func ParallelVerifyThreshold() int {
+	return int(parallelVerifyThreshold.Load())
+}
+
+var (
+	parallelCalibration sync.Once
+	calibratedThreshold int
+)
+
+// calibrationBatchSizes are the batch sizes CalibrateParallelThreshold tries.
+var calibrationBatchSizes = []int{2, 4, 8, 16, 32}
+
+// CalibrateParallelThreshold times serial and parallel verification of
+// growing batches on this machine and returns the smallest size at which
+// parallel is clearly faster. If parallel never wins, as with a single CPU,
+// it returns math.MaxInt so that VerifyAuto always runs serially. It takes
+// tens of milliseconds.
+func CalibrateParallelThreshold() int {
+	if MaxVerifyConcurrency() <= 1 {
+		return math.MaxInt
+	}
+	skHex, pubHex := ExampleKeyPair()
+	msg := "bls-sig calibration"
+	sigHex, err := GenerateSignature(skHex, []byte(msg))
+	if err != nil {
+		return math.MaxInt
+	}
+	entries := make([]VerifyTuple, calibrationBatchSizes[len(calibrationBatchSizes)-1])
+	for i := range entries {
+		entries[i] = VerifyTuple{PubKey: pubHex, Signature: sigHex, Message: msg}
+	}
+
+	threshold := math.MaxInt
+	for _, n := range calibrationBatchSizes {
+		start := time.Now()
+		verifySerial(entries[:n])
+		serial := time.Since(start)
+		start = time.Now()
+		VerifyParallel(entries[:n])
+		// Require a clear win so timing noise doesn't pick parallel.
+		if time.Since(start) < serial*9/10 {
+			threshold = n
+			break
+		}
+	}
+	return threshold
+}
+
+// useParallel reports whether VerifyAuto takes the parallel path for n entries.
+func useParallel(n int) bool {
+	threshold := ParallelVerifyThreshold()
+	if threshold == 0 {
+		parallelCalibration.Do(func() { calibratedThreshold = CalibrateParallelThreshold() })
+		threshold = calibratedThreshold
+	}
+	return n >= threshold
+}
+
+func verifySerial(entries []VerifyTuple) ([]bool, error) {
+	results := make([]bool, len(entries))
+	for i, e := range entries {
+		ok, err := VerifySignature(e.PubKey, e.Signature, e.Message)
+		if err != nil {
+			return nil, batchElementError(i, err)
+		}
+		results[i] = ok
+	}
+	return results, nil
+}
+
+// VerifyAuto reports whether every entry verifies, verifying serially for
+// small batches and with VerifyParallel once the batch reaches the parallel
+// threshold.
+func VerifyAuto(entries []VerifyTuple) (bool, error) {
+	if len(entries) == 0 {
+		return false, ErrNoSignatures
+	}
+	verify := verifySerial
+	if useParallel(len(entries)) {
+		verify = VerifyParallel
+	}
+	results, err := verify(entries)
+	if err != nil {
+		return false, err
+	}
+	for _, ok := range results {
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
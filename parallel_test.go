@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMaxVerifyConcurrencyRespectedUnderBurst(t *testing.T) {
+	const limit = 2
+	prev := MaxVerifyConcurrency()
+	SetMaxVerifyConcurrency(limit)
+	t.Cleanup(func() { SetMaxVerifyConcurrency(prev) })
+
+	entries := testTuples(t, 8)
+	done := make(chan struct{})
+	peak := make(chan int)
+	go func() {
+		most := 0
+		for {
+			select {
+			case <-done:
+				peak <- most
+				return
+			default:
+			}
+			verifySlots.mu.Lock()
+			most = max(most, verifySlots.active)
+			verifySlots.mu.Unlock()
+			time.Sleep(10 * time.Microsecond)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := VerifyParallel(entries)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for j, ok := range results {
+				if !ok {
+					t.Errorf("entry %d did not verify", j)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	if got := <-peak; got > limit {
+		t.Fatalf("%d verifications ran at once, limit %d", got, limit)
+	}
+}
+
+func TestVerifyAuto(t *testing.T) {
+	SetParallelVerifyThreshold(4)
+	t.Cleanup(func() { SetParallelVerifyThreshold(0) })
+	if useParallel(3) || !useParallel(4) || !useParallel(10) {
+		t.Fatalf("with threshold 4: useParallel(3) = %v, (4) = %v, (10) = %v; want false, true, true",
+			useParallel(3), useParallel(4), useParallel(10))
+	}
+
+	for _, n := range []int{2, 10} {
+		entries := testTuples(t, n)
+		if ok, err := VerifyAuto(entries); err != nil || !ok {
+			t.Fatalf("%d valid entries: %v, %v; want true", n, ok, err)
+		}
+		entries[n-1].Message = "tampered"
+		if ok, err := VerifyAuto(entries); err != nil || ok {
+			t.Fatalf("%d entries, last invalid: %v, %v; want false", n, ok, err)
+		}
+	}
+	if _, err := VerifyAuto(nil); !errors.Is(err, ErrNoSignatures) {
+		t.Fatalf("empty batch: err = %v, want ErrNoSignatures", err)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+var (
+	ErrBitfieldLength       = errors.New("participation bitfield length does not match committee")
+	ErrWeightOverflow       = errors.New("participating weight overflows uint64")
+	ErrStakeThreshold       = errors.New("stake threshold must be a fraction in (0, 1]")
+	ErrThresholdUnreachable = errors.New("total stake is below the threshold")
+	ErrZeroStake            = errors.New("committee has no stake")
+)
+
+// Participation bitfields mark committee member i in bit i%8 of byte i/8, the
+// SSZ Bitvector layout.
+
+func checkBitfield(bits []byte, n int) error {
+	if len(bits) != (n+7)/8 {
+		return fmt.Errorf("%w: %d bytes for %d members", ErrBitfieldLength, len(bits), n)
+	}
+	if n%8 != 0 && bits[len(bits)-1]>>(n%8) != 0 {
+		return fmt.Errorf("%w: bits set beyond member %d", ErrBitfieldLength, n-1)
+	}
+	return nil
+}
+
+func bitSet(bits []byte, i int) bool {
+	return bits[i/8]&(1<<(i%8)) != 0
+}
+
+// VerifyWeighted verifies aggSigHex against the pre-aggregated public key and
+// sums weights[i] for every member i set in participation.
+func VerifyWeighted(aggPubKeyHex, aggSigHex, msg string, weights []uint64, participation []byte) (valid bool, totalWeight uint64, err error) {
+	if err := checkBitfield(participation, len(weights)); err != nil {
+		return false, 0, err
+	}
+	for i, w := range weights {
+		if !bitSet(participation, i) {
+			continue
+		}
+		if totalWeight > math.MaxUint64-w {
+			return false, 0, ErrWeightOverflow
+		}
+		totalWeight += w
+	}
+	valid, err = VerifySignature(aggPubKeyHex, aggSigHex, msg)
+	if err != nil {
+		return false, 0, err
+	}
+	return valid, totalWeight, nil
+}
+
+// ParticipatingSigners returns the committee members set in participation, in
+// committee order.
+func ParticipatingSigners(committeePubKeys []string, participation []byte) ([]string, error) {
+	if err := checkBitfield(participation, len(committeePubKeys)); err != nil {
+		return nil, err
+	}
+	var signers []string
+	for i, pk := range committeePubKeys {
+		if bitSet(participation, i) {
+			signers = append(signers, pk)
+		}
+	}
+	return signers, nil
+}
+
+// CanMergeAggregates reports whether two aggregates with participation
+// bitfields bitA and bitB can be merged: the bitfields must be the same length
+// and share no set bits. It does no cryptography, so it is cheap enough to
+// discard unmergeable gossip before verifying anything. Bitfields of different
+// lengths return ErrBitfieldLength.
+func CanMergeAggregates(bitA, bitB []byte) (bool, error) {
+	if len(bitA) != len(bitB) {
+		return false, fmt.Errorf("%w: %d and %d bytes", ErrBitfieldLength, len(bitA), len(bitB))
+	}
+	for i := range bitA {
+		if bitA[i]&bitB[i] != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// VerifyStakeThreshold reports whether aggSigHex is a valid aggregate over msg
+// from the committee members set in participation and whether their combined
+// stake is at least thresholdNumerator/thresholdDenominator of the committee's
+// total stake, e.g. 2/3 for finality. The stake check runs first, so an
+// aggregate short of the threshold is rejected without any pairing. A
+// committee whose stakes are all zero returns ErrZeroStake.
+func VerifyStakeThreshold(aggSigHex, msg string, committeePubKeys []string, stakes []uint64, participation []byte, thresholdNumerator, thresholdDenominator uint64) (bool, error) {
+	if thresholdNumerator == 0 || thresholdDenominator == 0 || thresholdNumerator > thresholdDenominator {
+		return false, fmt.Errorf("%w: %d/%d", ErrStakeThreshold, thresholdNumerator, thresholdDenominator)
+	}
+	if len(stakes) != len(committeePubKeys) {
+		return false, fmt.Errorf("%w: %d public keys, %d stakes", ErrLengthMismatch, len(committeePubKeys), len(stakes))
+	}
+	signers, err := ParticipatingSigners(committeePubKeys, participation)
+	if err != nil {
+		return false, err
+	}
+	var total, participating uint64
+	for i, s := range stakes {
+		if total > math.MaxUint64-s {
+			return false, ErrWeightOverflow
+		}
+		total += s
+		if bitSet(participation, i) {
+			participating += s
+		}
+	}
+	// With no stake at all the cross products below are both zero and any
+	// participation would pass.
+	if total == 0 {
+		return false, ErrZeroStake
+	}
+	// Compare participating/total >= num/den as 128-bit cross products.
+	pHi, pLo := bits.Mul64(participating, thresholdDenominator)
+	tHi, tLo := bits.Mul64(total, thresholdNumerator)
+	if pHi < tHi || (pHi == tHi && pLo < tLo) {
+		return false, nil
+	}
+	return FastAggregateVerify(aggSigHex, msg, signers)
+}
+
+// SelectSignersForThreshold returns the indices, ascending, of the fewest
+// signers whose stakes sum to at least threshold, taking the largest stakes
+// first; ties go to the lower index. It returns ErrThresholdUnreachable if all stakes
+// together fall short.
+func SelectSignersForThreshold(pubKeyHexes []string, stakes []uint64, threshold uint64) (indices []int, err error) {
+	if len(stakes) != len(pubKeyHexes) {
+		return nil, fmt.Errorf("%w: %d public keys, %d stakes", ErrLengthMismatch, len(pubKeyHexes), len(stakes))
+	}
+	order := make([]int, len(stakes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return stakes[order[a]] > stakes[order[b]] })
+	var sum uint64
+	for _, i := range order {
+		if sum >= threshold {
+			break
+		}
+		indices = append(indices, i)
+		if sum > math.MaxUint64-stakes[i] {
+			sum = math.MaxUint64
+		} else {
+			sum += stakes[i]
+		}
+	}
+	if sum < threshold {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrThresholdUnreachable, sum, threshold)
+	}
+	sort.Ints(indices)
+	return indices, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyWeighted(t *testing.T) {
+	_, pks, sigs := testSigners(t, 5, "checkpoint")
+	weights := []uint64{10, 20, 30, 40, 50}
+	// Members 0, 2 and 3 participate.
+	participation := []byte{0b01101}
+	aggPub, err := AggregatePublicKeys([]string{pks[0], pks[2], pks[3]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggSig := mustAggregate(t, []string{sigs[0], sigs[2], sigs[3]})
+
+	valid, total, err := VerifyWeighted(aggPub, aggSig, "checkpoint", weights, participation)
+	if err != nil || !valid {
+		t.Fatalf("VerifyWeighted = %v, %v; want valid", valid, err)
+	}
+	if total != 80 {
+		t.Fatalf("total weight %d, want 80", total)
+	}
+
+	if _, _, err := VerifyWeighted(aggPub, aggSig, "checkpoint", weights, []byte{0b100101}); !errors.Is(err, ErrBitfieldLength) {
+		t.Fatalf("bit beyond committee: err %v, want ErrBitfieldLength", err)
+	}
+}
+
+func TestParticipatingSigners(t *testing.T) {
+	committee := []string{"pk0", "pk1", "pk2", "pk3", "pk4"}
+	got, err := ParticipatingSigners(committee, []byte{0b10110})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"pk1", "pk2", "pk4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParticipatingSigners = %v, want %v", got, want)
+	}
+	if _, err := ParticipatingSigners(committee, []byte{0, 0}); !errors.Is(err, ErrBitfieldLength) {
+		t.Fatalf("two-byte bitfield: err = %v, want ErrBitfieldLength", err)
+	}
+}
+
+func TestCanMergeAggregates(t *testing.T) {
+	tests := []struct {
+		name       string
+		bitA, bitB []byte
+		want       bool
+		err        error
+	}{
+		{"disjoint", []byte{0b0011, 0x80}, []byte{0b1100, 0x01}, true, nil},
+		{"overlapping", []byte{0b0011, 0}, []byte{0b0110, 0}, false, nil},
+		{"mismatched length", []byte{0b0001}, []byte{0b0010, 0}, false, ErrBitfieldLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanMergeAggregates(tt.bitA, tt.bitB)
+			if got != tt.want || !errors.Is(err, tt.err) {
+				t.Fatalf("CanMergeAggregates = %v, %v; want %v, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestVerifyStakeThreshold(t *testing.T) {
+	const msg = "checkpoint"
+	_, pks, sigs := testSigners(t, 3, msg)
+	agg := mustAggregate(t, sigs[:2])
+	participation := []byte{0b011}
+
+	// 20 of 30 is exactly two thirds.
+	if ok, err := VerifyStakeThreshold(agg, msg, pks, []uint64{10, 10, 10}, participation, 2, 3); err != nil || !ok {
+		t.Fatalf("exactly 2/3: ok=%v err=%v", ok, err)
+	}
+	// 20 of 31 falls just short.
+	if ok, err := VerifyStakeThreshold(agg, msg, pks, []uint64{10, 10, 11}, participation, 2, 3); err != nil || ok {
+		t.Fatalf("just below 2/3: ok=%v err=%v", ok, err)
+	}
+	// Enough stake is not enough without a valid aggregate.
+	if ok, err := VerifyStakeThreshold(mustAggregate(t, sigs[:1]), msg, pks, []uint64{10, 10, 10}, participation, 2, 3); err != nil || ok {
+		t.Fatalf("aggregate missing a signer: ok=%v err=%v", ok, err)
+	}
+	if _, err := VerifyStakeThreshold(agg, msg, pks, []uint64{10, 10, 10}, participation, 4, 3); !errors.Is(err, ErrStakeThreshold) {
+		t.Fatalf("threshold above 1: err = %v, want ErrStakeThreshold", err)
+	}
+	if _, err := VerifyStakeThreshold(agg, msg, pks, []uint64{10, 10, 10}, participation, 2, 0); !errors.Is(err, ErrStakeThreshold) {
+		t.Fatalf("zero denominator: err = %v, want ErrStakeThreshold", err)
+	}
+	if ok, err := VerifyStakeThreshold(agg, msg, pks, []uint64{0, 0, 0}, participation, 2, 3); !errors.Is(err, ErrZeroStake) || ok {
+		t.Fatalf("zero total stake: ok=%v err=%v, want ErrZeroStake", ok, err)
+	}
+}
+
+func TestSelectSignersForThreshold(t *testing.T) {
+	pks := []string{"pk0", "pk1", "pk2", "pk3", "pk4"}
+	stakes := []uint64{5, 20, 1, 15, 20}
+	const threshold = 36
+	got, err := SelectSignersForThreshold(pks, stakes, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("selected %v, want %v", got, want)
+	}
+	var sum uint64
+	for _, i := range got {
+		sum += stakes[i]
+	}
+	if sum < threshold {
+		t.Fatalf("selected stake %d below threshold %d", sum, threshold)
+	}
+	// Minimal: dropping any selected signer falls short.
+	for _, i := range got {
+		if sum-stakes[i] >= threshold {
+			t.Fatalf("signer %d is not needed to reach %d", i, threshold)
+		}
+	}
+
+	if _, err := SelectSignersForThreshold(pks, stakes, 62); !errors.Is(err, ErrThresholdUnreachable) {
+		t.Fatalf("unreachable: err = %v, want ErrThresholdUnreachable", err)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"math/big"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// EIP-2537 encodes each base field element as 64 bytes: 16 zero bytes then the
+// 48-byte big-endian value. A G1 point is x || y (128 bytes) and a G2 point is
+// x.c0 || x.c1 || y.c0 || y.c1 (256 bytes).
+const (
+	precompileFpLen   = 64
+	precompileG1Len   = 2 * precompileFpLen
+	precompileG2Len   = 4 * precompileFpLen
+	precompilePairLen = precompileG1Len + precompileG2Len
+	fpLen             = 48
+)
+
+// fieldModulus is the BLS12-381 base field prime p.
+var fieldModulus, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+
+// EncodeForPrecompile returns the input for the EIP-2537 pairing-check
+// precompile that verifies sigHex over msg under pubKeyHex:
+//
+//	pk || H(m) || -G1 || sig
+//
+// that is, the check e(pk, H(m)) * e(-G1, sig) == 1. H(m) hashes msg's signing
+// root to G2 with the proof-of-possession DST, as VerifySignature does, so the
+// precompile returns 1 exactly when VerifySignature returns true.
+func EncodeForPrecompile(pubKeyHex, sigHex string, msg []byte) ([]byte, error) {
+	pk, err := blstPublicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := decodeSignaturePoint(b)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := SchemePOP.DST()
+	if err != nil {
+		return nil, err
+	}
+	root := signingRoot(msg)
+	hm := blst.HashToG2(root[:], dst).ToAffine()
+
+	out := make([]byte, 0, 2*precompilePairLen)
+	out = appendPrecompileG1(out, pk.Serialize())
+	out = appendPrecompileG2(out, hm.Serialize())
+	out = appendPrecompileG1(out, negG1(blst.P1Generator().ToAffine().Serialize()))
+	out = appendPrecompileG2(out, sig.Serialize())
+	return out, nil
+}
+
+// appendPrecompileG1 re-pads a 96-byte serialized G1 point (x || y).
+func appendPrecompileG1(out, p []byte) []byte {
+	out = appendPrecompileFp(out, p[:fpLen])
+	return appendPrecompileFp(out, p[fpLen:])
+}
+
+// appendPrecompileG2 re-pads a 192-byte serialized G2 point. blst serializes
+// each Fp2 coordinate as c1 || c0, but EIP-2537 wants c0 || c1.
+func appendPrecompileG2(out, p []byte) []byte {
+	out = appendPrecompileFp(out, p[fpLen:2*fpLen])
+	out = appendPrecompileFp(out, p[:fpLen])
+	out = appendPrecompileFp(out, p[3*fpLen:])
+	return appendPrecompileFp(out, p[2*fpLen:3*fpLen])
+}
+
+func appendPrecompileFp(out, fp []byte) []byte {
+	out = append(out, make([]byte, precompileFpLen-fpLen)...)
+	return append(out, fp...)
+}
+
+// negG1 negates a serialized affine G1 point by replacing y with p - y.
+func negG1(p []byte) []byte {
+	y := new(big.Int).SetBytes(p[fpLen:])
+	y.Sub(fieldModulus, y)
+	out := append([]byte(nil), p[:fpLen]...)
+	return append(out, y.FillBytes(make([]byte, fpLen))...)
+}
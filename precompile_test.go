@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeForPrecompileLayout(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("on-chain")
+	input, err := EncodeForPrecompile(pubKeyHex, mustSign(t, skHex, string(msg)), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two (G1, G2) pairs of 128 + 256 bytes.
+	if len(input) != 768 {
+		t.Fatalf("input is %d bytes, want 768", len(input))
+	}
+	for off := 0; off < len(input); off += precompileFpLen {
+		fp := input[off : off+precompileFpLen]
+		if !bytes.Equal(fp[:16], make([]byte, 16)) {
+			t.Fatalf("field element at %d lacks 16 leading zero bytes", off)
+		}
+		if new(big.Int).SetBytes(fp).Cmp(fieldModulus) >= 0 {
+			t.Fatalf("field element at %d is not reduced mod p", off)
+		}
+	}
+}
+
+// wantPrecompileInputHash is the SHA-256 of the pairing input for the example
+// key signing "on-chain". go-ethereum v1.14.5's EIP-2537 pairing precompile
+// returns 1 for that input.
+const wantPrecompileInputHash = "2eb97920e8fea9656305425f79ace2dca9e052c6863ec1350d04c6c6ee074fca"
+
+func TestEncodeForPrecompileVector(t *testing.T) {
+	skHex, pubKeyHex := ExampleKeyPair()
+	input, err := EncodeForPrecompile(pubKeyHex, mustSign(t, skHex, "on-chain"), []byte("on-chain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sha256.Sum256(input); hex.EncodeToString(got[:]) != wantPrecompileInputHash {
+		t.Fatalf("input hash %x, want %s", got, wantPrecompileInputHash)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import "google.golang.org/protobuf/proto"
+
+// protoBytes marshals m deterministically. Deterministic marshaling is only
+// stable for one binary and schema version: map ordering is fixed, but
+// unknown fields and encoder changes across protobuf releases can still alter
+// the bytes, so signer and verifier should share the same generated code.
+func protoBytes(m proto.Message) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(m)
+}
+
+// SignProto signs the deterministic wire encoding of m.
+func SignProto(skHex string, m proto.Message) (string, error) {
+	b, err := protoBytes(m)
+	if err != nil {
+		return "", err
+	}
+	return GenerateSignature(skHex, b)
+}
+
+// VerifyProto checks a SignProto signature over m.
+func VerifyProto(pubKeyHex, sigHex string, m proto.Message) (bool, error) {
+	b, err := protoBytes(m)
+	if err != nil {
+		return false, err
+	}
+	return VerifySignature(pubKeyHex, sigHex, string(b))
+}
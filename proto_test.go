@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSignProto(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	// A struct is a map, so only deterministic marshaling gives stable bytes.
+	m, err := structpb.NewStruct(map[string]any{"slot": 12, "proposer": "v7", "graffiti": "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := SignProto(skHex, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if again, err := SignProto(skHex, m); err != nil || again != sig {
+			t.Fatalf("signing the same message again gave %s, %v; want %s", again, err, sig)
+		}
+	}
+	if ok, err := VerifyProto(pubKeyHex, sig, m); err != nil || !ok {
+		t.Fatalf("VerifyProto = %v, %v; want true", ok, err)
+	}
+	m.Fields["slot"] = structpb.NewNumberValue(13)
+	if ok, err := VerifyProto(pubKeyHex, sig, m); err != nil || ok {
+		t.Fatalf("changed message: %v, %v; want false", ok, err)
+	}
+}
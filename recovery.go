@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidRecoveryBlob = errors.New("recovery blob is corrupt or was sealed with a different key")
+
+// RecoverySigner signs with recovery blobs sealed under an operator key. OpKey
+// is 16, 24 or 32 bytes for AES-128, -192 or -256, and is the key
+// DecodeRecovery needs to open the blobs.
+type RecoverySigner struct {
+	OpKey []byte
+}
+
+// RecoveryInfo is the signing context sealed in a recovery blob.
+type RecoveryInfo struct {
+	PubKey      string    `json:"pubkey"`
+	MessageHash string    `json:"message_hash"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Verify re-checks sigHex against the recorded signer and message hash.
+func (r RecoveryInfo) Verify(sigHex string) (bool, error) {
+	h, err := decodeHex(r.MessageHash)
+	if err != nil {
+		return false, fmt.Errorf("message hash: %w", err)
+	}
+	if len(h) != 32 {
+		return false, ErrInvalidRecoveryBlob
+	}
+	return verifyRoot(r.PubKey, sigHex, [32]byte(h))
+}
+
+// SignWithRecovery signs msg like GenerateSignature and also returns a blob
+// that seals the public key, message hash and signing time under s.OpKey, so
+// the signing event can be reconstructed and re-verified later with
+// DecodeRecovery. The blob is AES-GCM encrypted and authenticated.
+func (s RecoverySigner) SignWithRecovery(skHex string, msg []byte) (sigHex string, recoveryBlob string, err error) {
+	aead, err := recoveryAEAD(s.OpKey)
+	if err != nil {
+		return "", "", err
+	}
+	if err := checkMessageSize(len(msg)); err != nil {
+		return "", "", err
+	}
+	sk, err := secretKeyFromHex(skHex)
+	if err != nil {
+		return "", "", err
+	}
+	root := signingRoot(msg)
+	sigHex = EncodingEthereum.Encode(sk.Sign(root[:]).Marshal())
+	info, err := json.Marshal(RecoveryInfo{
+		PubKey:      EncodingEthereum.Encode(sk.PublicKey().Marshal()),
+		MessageHash: EncodingEthereum.Encode(root[:]),
+		Timestamp:   time.Now().UTC(),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return "", "", err
+	}
+	return sigHex, EncodingEthereum.Encode(aead.Seal(nonce, nonce, info, nil)), nil
+}
+
+// DecodeRecovery opens a RecoverySigner.SignWithRecovery blob with the
+// operator key.
+func DecodeRecovery(blob string, opKey []byte) (RecoveryInfo, error) {
+	b, err := decodeHex(blob)
+	if err != nil {
+		return RecoveryInfo{}, fmt.Errorf("recovery blob: %w", err)
+	}
+	aead, err := recoveryAEAD(opKey)
+	if err != nil {
+		return RecoveryInfo{}, err
+	}
+	if len(b) < aead.NonceSize() {
+		return RecoveryInfo{}, ErrInvalidRecoveryBlob
+	}
+	info, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return RecoveryInfo{}, ErrInvalidRecoveryBlob
+	}
+	var r RecoveryInfo
+	if err := json.Unmarshal(info, &r); err != nil {
+		return RecoveryInfo{}, fmt.Errorf("%w: %v", ErrInvalidRecoveryBlob, err)
+	}
+	return r, nil
+}
+
+func recoveryAEAD(opKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(opKey)
+	if err != nil {
+		return nil, fmt.Errorf("recovery key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignWithRecoveryRoundTrip(t *testing.T) {
+	opKey := make([]byte, 32)
+	for i := range opKey {
+		opKey[i] = byte(i)
+	}
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := []byte("withdraw 5")
+
+	before := time.Now().Add(-time.Second)
+	sig, blob, err := RecoverySigner{OpKey: opKey}.SignWithRecovery(skHex, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := DecodeRecovery(blob, opKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := signingRoot(msg)
+	if info.PubKey != pubKeyHex || info.MessageHash != EncodingEthereum.Encode(root[:]) {
+		t.Fatalf("recovered %+v, want pubkey %s and the message hash", info, pubKeyHex)
+	}
+	if info.Timestamp.Before(before) || info.Timestamp.After(time.Now()) {
+		t.Fatalf("recovered timestamp %v is not the signing time", info.Timestamp)
+	}
+	if ok, err := info.Verify(sig); err != nil || !ok {
+		t.Fatalf("re-verifying from the recovery info: %v, %v; want true", ok, err)
+	}
+
+	otherKey := make([]byte, 32)
+	if _, err := DecodeRecovery(blob, otherKey); !errors.Is(err, ErrInvalidRecoveryBlob) {
+		t.Fatalf("wrong operator key: err = %v, want ErrInvalidRecoveryBlob", err)
+	}
+}
+
+func TestSignWithRecoveryBadOpKey(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	if _, _, err := (RecoverySigner{OpKey: make([]byte, 15)}).SignWithRecovery(skHex, []byte("x")); err == nil {
+		t.Fatal("15-byte operator key accepted")
+	}
+	if _, _, err := (RecoverySigner{}).SignWithRecovery(skHex, []byte("x")); err == nil {
+		t.Fatal("missing operator key accepted")
+	}
+}
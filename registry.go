@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrUnknownIndex   = errors.New("validator index not in registry")
+	ErrDuplicateAlias = errors.New("alias used by more than one validator")
+)
+
+// KeyRegistry verifies aggregate signatures from signers named by validator
+// index or alias. Registry and SQLRegistry implement it.
+type KeyRegistry interface {
+	VerifyByIndices(aggSigHex, msg string, indices []uint64) (bool, error)
+	VerifyByAlias(aggSigHex, msg string, aliases []string) (bool, error)
+}
+
+var (
+	_ KeyRegistry = (*Registry)(nil)
+	_ KeyRegistry = (*SQLRegistry)(nil)
+)
+
+// Registry maps validator indices, and optionally aliases, to public keys. It
+// is read-only after loading and safe for concurrent use.
+type Registry struct {
+	pubKeys map[uint64]string
+	aliases map[string]string
+}
+
+// registryEntry is the object form of a registry value, naming an alias.
+type registryEntry struct {
+	PubKey string `json:"pubkey"`
+	Alias  string `json:"alias"`
+}
+
+// LoadCommitteeRegistry reads a JSON object mapping validator indices to hex
+// public keys, such as {"0": "0x...", "1": "0x..."}. A value may instead be
+// an object {"pubkey": "0x...", "alias": "name"} to make the key reachable by
+// alias too. Every key is validated and stored in canonical compressed form.
+func LoadCommitteeRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[uint64]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse registry: %w", err)
+	}
+	r := &Registry{
+		pubKeys: make(map[uint64]string, len(raw)),
+		aliases: make(map[string]string),
+	}
+	for idx, v := range raw {
+		var e registryEntry
+		if err := json.Unmarshal(v, &e.PubKey); err != nil {
+			if err := json.Unmarshal(v, &e); err != nil {
+				return nil, fmt.Errorf("parse registry: index %d: %w", idx, err)
+			}
+		}
+		b, err := decodeHex(e.PubKey)
+		if err == nil {
+			b, err = canonicalPubKeyBytes(b)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", idx, err)
+		}
+		pk := EncodingEthereum.Encode(b)
+		r.pubKeys[idx] = pk
+		if e.Alias != "" {
+			if _, ok := r.aliases[e.Alias]; ok {
+				return nil, fmt.Errorf("%w: %q", ErrDuplicateAlias, e.Alias)
+			}
+			r.aliases[e.Alias] = pk
+		}
+	}
+	return r, nil
+}
+
+// VerifyByIndices looks up the public keys for indices and checks aggSigHex
+// as their aggregate signature over msg. An index missing from the registry
+// returns ErrUnknownIndex.
+func (r *Registry) VerifyByIndices(aggSigHex, msg string, indices []uint64) (bool, error) {
+	if len(indices) == 0 {
+		return false, ErrNoPublicKeys
+	}
+	pks := make([]string, len(indices))
+	for i, idx := range indices {
+		pk, ok := r.pubKeys[idx]
+		if !ok {
+			return false, fmt.Errorf("%w: %d", ErrUnknownIndex, idx)
+		}
+		pks[i] = pk
+	}
+	return FastAggregateVerify(aggSigHex, msg, pks)
+}
+
+// VerifyByAlias is VerifyByAlias with the registry's aliases. An alias missing
+// from the registry returns ErrUnknownAlias.
+func (r *Registry) VerifyByAlias(aggSigHex, msg string, aliases []string) (bool, error) {
+	return VerifyByAlias(r.aliases, aggSigHex, msg, aliases)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestRegistry writes pubKeys as a JSON registry keyed by position.
+func writeTestRegistry(t *testing.T, pubKeys []string) string {
+	t.Helper()
+	raw := make(map[uint64]string, len(pubKeys))
+	for i, pk := range pubKeys {
+		raw[uint64(i)] = pk
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "registry.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRegistryVerifyByIndices(t *testing.T) {
+	_, pks, sigs := testSigners(t, 5, "epoch 3")
+	r, err := LoadCommitteeRegistry(writeTestRegistry(t, pks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	agg := mustAggregate(t, []string{sigs[0], sigs[2], sigs[4]})
+	if ok, err := r.VerifyByIndices(agg, "epoch 3", []uint64{0, 2, 4}); err != nil || !ok {
+		t.Fatalf("indices 0, 2, 4: %v, %v; want true", ok, err)
+	}
+	if ok, err := r.VerifyByIndices(agg, "epoch 3", []uint64{0, 1, 4}); err != nil || ok {
+		t.Fatalf("wrong indices: %v, %v; want false", ok, err)
+	}
+	if _, err := r.VerifyByIndices(agg, "epoch 3", []uint64{0, 2, 9}); !errors.Is(err, ErrUnknownIndex) {
+		t.Fatalf("unknown index: err = %v, want ErrUnknownIndex", err)
+	}
+}
+
+func TestRegistryVerifyByAlias(t *testing.T) {
+	_, pks, sigs := testSigners(t, 3, "epoch 4")
+	data, err := json.Marshal(map[uint64]any{
+		0: registryEntry{PubKey: pks[0], Alias: "alice"},
+		1: registryEntry{PubKey: pks[1], Alias: "bob"},
+		2: pks[2],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "registry.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var r KeyRegistry
+	if r, err = LoadCommitteeRegistry(path); err != nil {
+		t.Fatal(err)
+	}
+	agg := mustAggregate(t, sigs[:2])
+	if ok, err := r.VerifyByAlias(agg, "epoch 4", []string{"alice", "bob"}); err != nil || !ok {
+		t.Fatalf("aliases: ok=%v err=%v", ok, err)
+	}
+	if ok, err := r.VerifyByIndices(agg, "epoch 4", []uint64{0, 1}); err != nil || !ok {
+		t.Fatalf("indices of aliased entries: ok=%v err=%v", ok, err)
+	}
+	if _, err := r.VerifyByAlias(agg, "epoch 4", []string{"alice", "carol"}); !errors.Is(err, ErrUnknownAlias) {
+		t.Fatalf("unknown alias: err = %v, want ErrUnknownAlias", err)
+	}
+}
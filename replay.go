@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrUnknownKeyID = errors.New("unknown key id")
+
+// KeyStore holds signers by key ID. It is safe for concurrent use.
+type KeyStore struct {
+	mu      sync.RWMutex
+	signers map[string]Signer
+}
+
+// NewKeyStore returns an empty KeyStore.
+func NewKeyStore() *KeyStore {
+	return &KeyStore{signers: make(map[string]Signer)}
+}
+
+// Add stores signer under id, replacing any signer already there.
+func (k *KeyStore) Add(id string, signer Signer) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.signers[id] = signer
+}
+
+// Signer returns the signer stored under id, or ErrUnknownKeyID.
+func (k *KeyStore) Signer(id string) (Signer, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	s, ok := k.signers[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, id)
+	}
+	return s, nil
+}
+
+// SigningEvent is one entry of a signing log: the key that signed, the
+// message and the signature recorded at the time.
+type SigningEvent struct {
+	KeyID     string `json:"key_id"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// ReplaySigningLog re-signs each logged message with the key it names and
+// reports, per event, whether the result matches the logged signature. BLS
+// signing is deterministic, so any mismatch means the entry was altered or
+// signed with a different key. A logged signature that doesn't decode counts
+// as a mismatch; a key ID missing from keyStore is an error.
+func ReplaySigningLog(keyStore *KeyStore, log []SigningEvent) ([]bool, error) {
+	matches := make([]bool, len(log))
+	for i, e := range log {
+		signer, err := keyStore.Signer(e.KeyID)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		sigHex, err := signer.Sign([]byte(e.Message))
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		want, _ := decodeHex(sigHex)
+		got, err := decodeHex(e.Signature)
+		matches[i] = err == nil && bytes.Equal(got, want)
+	}
+	return matches, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReplaySigningLog(t *testing.T) {
+	ks := NewKeyStore()
+	var log []SigningEvent
+	for _, id := range []string{"hot", "cold"} {
+		skHex, _ := testKeyPair(t)
+		s, err := NewLocalSigner(skHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ks.Add(id, s)
+		for _, msg := range []string{"withdraw 1", "withdraw 2"} {
+			log = append(log, SigningEvent{KeyID: id, Message: msg, Signature: mustSign(t, skHex, msg)})
+		}
+	}
+	log[2].Message = "withdraw 100"
+	log[3].Signature = "0xnot-hex"
+
+	got, err := ReplaySigningLog(ks, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []bool{true, true, false, false}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("matches = %v, want %v", got, want)
+	}
+
+	log = append(log, SigningEvent{KeyID: "lost", Message: "x"})
+	if _, err := ReplaySigningLog(ks, log); !errors.Is(err, ErrUnknownKeyID) {
+		t.Fatalf("unknown key: err = %v, want ErrUnknownKeyID", err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var ErrEmptyDST = errors.New("empty domain separation tag")
+
+// RollupDST is the Ethereum proof-of-possession tag that on-chain verifiers
+// built on the EIP-2537 precompiles commonly hash to G2 with. Pass it to
+// SignWithDST and VerifyRollupAggregate unless the deployment uses its own.
+const RollupDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+// SignWithDST signs msg itself, not its SHA-256, hashing it to G2 under dst.
+// A precompile-based verifier hashes exactly the bytes it is given, so use
+// this rather than GenerateSignature for signatures checked on-chain.
+func SignWithDST(skHex string, msg, dst []byte) (string, error) {
+	if len(dst) == 0 {
+		return "", ErrEmptyDST
+	}
+	if err := checkMessageSize(len(msg)); err != nil {
+		return "", err
+	}
+	sk, err := blstSecretKeyFromHex(skHex)
+	if err != nil {
+		return "", err
+	}
+	defer sk.Zeroize()
+	return EncodingEthereum.Encode(new(blst.P2Affine).Sign(sk, msg, dst).Compress()), nil
+}
+
+// VerifyRollupAggregate checks an aggregate signature in G2 over msg against
+// the sum of pubKeyHexes in G1, hashing msg to G2 under dst. Like SignWithDST
+// and the on-chain verifiers it mirrors, it does not pre-hash msg, so
+// signatures from GenerateSignature do not verify here.
+func VerifyRollupAggregate(aggSigHex, msg string, pubKeyHexes []string, dst []byte) (bool, error) {
+	if len(dst) == 0 {
+		return false, ErrEmptyDST
+	}
+	if len(pubKeyHexes) == 0 {
+		return false, ErrNoPublicKeys
+	}
+	b, err := decodeHex(aggSigHex)
+	if err != nil {
+		return false, fmt.Errorf("signature: %w", err)
+	}
+	if err := checkSignatureLength(b, SignatureLength); err != nil {
+		return false, err
+	}
+	sig, err := decodeSignaturePoint(b)
+	if err != nil {
+		return false, err
+	}
+	pks := make([]*blst.P1Affine, len(pubKeyHexes))
+	for i, h := range pubKeyHexes {
+		if pks[i], err = blstPublicKeyFromHex(h); err != nil {
+			return false, fmt.Errorf("public key %d: %w", i, err)
+		}
+	}
+	return sig.FastAggregateVerify(false, pks, []byte(msg), dst), nil
+}
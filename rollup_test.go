@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+func TestRollupAggregateSelfConsistent(t *testing.T) {
+	dst := []byte(RollupDST)
+	msg := "batch 1234"
+	var pks, sigs []string
+	for i := 0; i < 3; i++ {
+		sk, pk := testKeyPair(t)
+		sig, err := SignWithDST(sk, []byte(msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pks = append(pks, pk)
+		sigs = append(sigs, sig)
+	}
+	agg := mustAggregate(t, sigs)
+
+	if ok, err := VerifyRollupAggregate(agg, msg, pks, dst); err != nil || !ok {
+		t.Fatalf("VerifyRollupAggregate = %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyRollupAggregate(agg, msg, pks, []byte("OTHER_ROLLUP_DST_")); err != nil || ok {
+		t.Fatalf("other DST: %v, %v; want false", ok, err)
+	}
+	// GenerateSignature pre-hashes with SHA-256, which an on-chain verifier
+	// does not.
+	if ok, err := FastAggregateVerify(agg, msg, pks); err != nil || ok {
+		t.Fatalf("pre-hashed path accepted a raw-message aggregate: %v, %v", ok, err)
+	}
+
+	b, err := decodeHex(agg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed := EncodingEthereum.Encode(new(blst.P2Affine).Uncompress(b).Serialize())
+	if _, err := VerifyRollupAggregate(uncompressed, msg, pks, dst); !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("192-byte signature: err = %v, want ErrTrailingBytes", err)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import "fmt"
+
+// rotationDomain separates rotation links from signatures over ordinary messages.
+var rotationDomain = []byte("bls-sig key rotation")
+
+func rotationRoot(newPubKeyHex string) ([32]byte, error) {
+	b, err := decodeHex(newPubKeyHex)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("new public key: %w", err)
+	}
+	if b, err = canonicalPubKeyBytes(b); err != nil {
+		return [32]byte{}, fmt.Errorf("new public key: %w", err)
+	}
+	return BuildSigningInput(rotationDomain, b), nil
+}
+
+// RotateKey generates a successor key and has the old key sign the new public
+// key. The link signature proves the old key holder authorised the rotation.
+func RotateKey(oldSkHex string) (newSkHex, newPubKeyHex, linkSigHex string, err error) {
+	if _, err := secretKeyFromHex(oldSkHex); err != nil {
+		return "", "", "", err
+	}
+	newSkHex, newPubKeyHex, err = GenerateKeyPair()
+	if err != nil {
+		return "", "", "", err
+	}
+	root, err := rotationRoot(newPubKeyHex)
+	if err != nil {
+		return "", "", "", err
+	}
+	linkSigHex, err = signRoot(oldSkHex, root)
+	if err != nil {
+		return "", "", "", err
+	}
+	return newSkHex, newPubKeyHex, linkSigHex, nil
+}
+
+// VerifyRotation checks that linkSigHex is the old key's signature over the
+// new public key, as produced by RotateKey.
+func VerifyRotation(oldPubKeyHex, newPubKeyHex, linkSigHex string) (bool, error) {
+	root, err := rotationRoot(newPubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	return verifyRoot(oldPubKeyHex, linkSigHex, root)
+}
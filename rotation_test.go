@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestRotateKey(t *testing.T) {
+	oldSK, oldPK := testKeyPair(t)
+	newSK, newPK, link, err := RotateKey(oldSK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mustPubKey(t, newSK) != newPK {
+		t.Fatal("new public key does not match the new secret key")
+	}
+	if ok, err := VerifyRotation(oldPK, newPK, link); err != nil || !ok {
+		t.Fatalf("valid link: %v, %v; want true", ok, err)
+	}
+
+	// An attacker with their own key cannot link it to the old one.
+	attackerSK, attackerPK := testKeyPair(t)
+	forged, err := signRoot(attackerSK, mustRotationRoot(t, attackerPK))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyRotation(oldPK, attackerPK, forged); err != nil || ok {
+		t.Fatalf("forged link: %v, %v; want false", ok, err)
+	}
+	if ok, err := VerifyRotation(oldPK, attackerPK, link); err != nil || ok {
+		t.Fatalf("link reused for another key: %v, %v; want false", ok, err)
+	}
+}
+
+func mustRotationRoot(t *testing.T, pubKeyHex string) [32]byte {
+	t.Helper()
+	root, err := rotationRoot(pubKeyHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// Scheme identifies a BLS signature variant by its domain separation tag. Its
+// value doubles as the leading tag byte of a tagged signature.
+type Scheme byte
+
+const (
+	// SchemePOP is the proof-of-possession scheme used by Ethereum and by the
+	// untagged functions in this package.
+	SchemePOP Scheme = 0x01
+	// SchemeBasic is the basic scheme, which requires distinct messages when aggregating.
+	SchemeBasic Scheme = 0x02
+)
+
+// Serialized sizes in bytes of compressed keys and signatures. Both schemes
+// put public keys in G1 and signatures in G2, so they share these lengths.
+const (
+	PubKeyLength    = blst.BLST_P1_COMPRESS_BYTES
+	SignatureLength = blst.BLST_P2_COMPRESS_BYTES
+	SecretKeyLength = 32
+)
+
+// Lengths holds the serialized sizes used by a scheme.
+type Lengths struct {
+	PubKey    int
+	Signature int
+	SecretKey int
+}
+
+var (
+	ErrUnknownScheme    = errors.New("unknown signature scheme")
+	ErrInvalidSecretKey = errors.New("invalid secret key")
+	ErrInvalidPublicKey = errors.New("invalid public key")
+)
+
+var schemeDSTs = map[Scheme][]byte{
+	SchemePOP:   []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"),
+	SchemeBasic: []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"),
+}
+
+// DST returns the scheme's domain separation tag.
+func (s Scheme) DST() ([]byte, error) {
+	dst, ok := schemeDSTs[s]
+	if !ok {
+		return nil, fmt.Errorf("%w: 0x%02x", ErrUnknownScheme, byte(s))
+	}
+	return dst, nil
+}
+
+// LengthsForScheme returns the serialized key and signature sizes for s.
+func LengthsForScheme(s Scheme) (Lengths, error) {
+	if _, err := s.DST(); err != nil {
+		return Lengths{}, err
+	}
+	return Lengths{PubKey: PubKeyLength, Signature: SignatureLength, SecretKey: SecretKeyLength}, nil
+}
+
+func blstSecretKeyFromHex(skHex string) (*blst.SecretKey, error) {
+	b, err := decodeHex(skHex)
+	if err != nil {
+		return nil, fmt.Errorf("secret key: %w", ErrInvalidHex)
+	}
+	defer zeroize(b)
+	if err := checkSecretKeyRange(b); err != nil {
+		return nil, err
+	}
+	sk := new(blst.SecretKey).Deserialize(b)
+	if sk == nil || !sk.Valid() {
+		return nil, ErrInvalidSecretKey
+	}
+	return sk, nil
+}
+
+func blstPublicKeyFromHex(pubKeyHex string) (*blst.P1Affine, error) {
+	b, err := decodeHex(pubKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	if err := checkPubKeyLength(b); err != nil {
+		return nil, err
+	}
+	pk := new(blst.P1Affine).Uncompress(b)
+	if pk == nil || !pk.KeyValidate() {
+		return nil, ErrInvalidPublicKey
+	}
+	return pk, nil
+}
+
+// SignTagged signs msg under scheme and prefixes the signature with the
+// scheme's tag byte.
+func SignTagged(skHex string, msg []byte, scheme Scheme) (string, error) {
+	dst, err := scheme.DST()
+	if err != nil {
+		return "", err
+	}
+	sk, err := blstSecretKeyFromHex(skHex)
+	if err != nil {
+		return "", err
+	}
+	defer sk.Zeroize()
+	root := signingRoot(msg)
+	sig := new(blst.P2Affine).Sign(sk, root[:], dst)
+	return EncodingEthereum.Encode(append([]byte{byte(scheme)}, sig.Compress()...)), nil
+}
+
+// VerifyTagged reads the scheme tag from the first byte of taggedSigHex and
+// verifies the rest of the signature under that scheme's DST.
+func VerifyTagged(pubKeyHex, taggedSigHex, msg string) (bool, error) {
+	tagged, err := decodeHex(taggedSigHex)
+	if err != nil {
+		return false, fmt.Errorf("signature: %w", err)
+	}
+	if len(tagged) == 0 {
+		return false, ErrInvalidSignature
+	}
+	dst, err := Scheme(tagged[0]).DST()
+	if err != nil {
+		return false, err
+	}
+	sig, err := decodeSignaturePoint(tagged[1:])
+	if err != nil {
+		return false, err
+	}
+	pk, err := blstPublicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	root := signingRoot([]byte(msg))
+	return sig.Verify(false, pk, false, root[:], dst), nil
+}
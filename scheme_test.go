@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyTagged(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	for _, scheme := range []Scheme{SchemePOP, SchemeBasic} {
+		tagged, err := SignTagged(skHex, []byte("hello"), scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := VerifyTagged(pubKeyHex, tagged, "hello"); err != nil || !ok {
+			t.Fatalf("scheme 0x%02x: %v, %v; want true", byte(scheme), ok, err)
+		}
+		b, err := decodeHex(tagged)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b[0] ^= byte(SchemePOP) ^ byte(SchemeBasic)
+		if ok, err := VerifyTagged(pubKeyHex, EncodingEthereum.Encode(b), "hello"); err != nil || ok {
+			t.Fatalf("scheme 0x%02x under the other tag: %v, %v; want false", byte(scheme), ok, err)
+		}
+	}
+
+	tagged, err := SignTagged(skHex, []byte("hello"), SchemePOP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := decodeHex(tagged)
+	b[0] = 0x7f
+	if _, err := VerifyTagged(pubKeyHex, EncodingEthereum.Encode(b), "hello"); !errors.Is(err, ErrUnknownScheme) {
+		t.Fatalf("unknown tag: err = %v, want ErrUnknownScheme", err)
+	}
+}
+
+func TestLengthsMatchMarshaledSizes(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "sizes")
+	for _, s := range []Scheme{SchemePOP, SchemeBasic} {
+		l, err := LengthsForScheme(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, c := range map[string]struct {
+			hex  string
+			want int
+		}{
+			"public key": {pubKeyHex, l.PubKey},
+			"signature":  {sig, l.Signature},
+			"secret key": {skHex, l.SecretKey},
+		} {
+			b, err := decodeHex(c.hex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(b) != c.want {
+				t.Errorf("scheme 0x%02x %s: %d bytes, LengthsForScheme says %d", byte(s), name, len(b), c.want)
+			}
+		}
+	}
+	if _, err := LengthsForScheme(Scheme(0x7f)); !errors.Is(err, ErrUnknownScheme) {
+		t.Fatalf("unknown scheme: err = %v, want ErrUnknownScheme", err)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSealedMessage(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	bid := []byte("bid: 42 ETH")
+	commitment, opening, err := SealMessage(bid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := SignSealed(skHex, commitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := OpenAndVerify(pubKeyHex, sig, commitment, bid, opening); err != nil || !ok {
+		t.Fatalf("correct opening: %v, %v; want true", ok, err)
+	}
+
+	wrong := append([]byte(nil), opening...)
+	wrong[0] ^= 1
+	if _, err := OpenAndVerify(pubKeyHex, sig, commitment, bid, wrong); !errors.Is(err, ErrBadOpening) {
+		t.Fatalf("mismatched opening: err = %v, want ErrBadOpening", err)
+	}
+	if _, err := OpenAndVerify(pubKeyHex, sig, commitment, []byte("bid: 1 ETH"), opening); !errors.Is(err, ErrBadOpening) {
+		t.Fatalf("different message: err = %v, want ErrBadOpening", err)
+	}
+}
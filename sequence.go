@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+// sequenceDomain separates sequenced signatures from signatures over ordinary messages.
+var sequenceDomain = []byte("bls-sig sequenced")
+
+// sequencedMessage is what a SequencedSigner actually signs: the root binding
+// seq to msg.
+func sequencedMessage(msg []byte, seq uint64) []byte {
+	var s [8]byte
+	binary.BigEndian.PutUint64(s[:], seq)
+	root := BuildSigningInput(sequenceDomain, s[:], msg)
+	return root[:]
+}
+
+// SequencedSigner numbers the messages it signs, binding each signature to
+// its sequence number so verifiers can detect gaps, reordering and replays.
+// It is safe for concurrent use; numbers are handed out in call order.
+type SequencedSigner struct {
+	mu     sync.Mutex
+	signer Signer
+	next   uint64
+}
+
+// NewSequencedSigner wraps signer, numbering messages from next.
+func NewSequencedSigner(signer Signer, next uint64) *SequencedSigner {
+	return &SequencedSigner{signer: signer, next: next}
+}
+
+// SignNext signs msg with the next sequence number and returns both. A failed
+// signature does not consume a number.
+func (s *SequencedSigner) SignNext(msg []byte) (sigHex string, seq uint64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seq = s.next
+	sigHex, err = s.signer.Sign(sequencedMessage(msg, seq))
+	if err != nil {
+		return "", 0, err
+	}
+	s.next++
+	return sigHex, seq, nil
+}
+
+// VerifySequenced checks that sigHex signs msg at sequence number seq. A
+// signature presented with any other number fails.
+func VerifySequenced(pubKeyHex, sigHex string, msg []byte, seq uint64) (bool, error) {
+	return VerifySignature(pubKeyHex, sigHex, string(sequencedMessage(msg, seq)))
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestSequencedSigner(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	local, err := NewLocalSigner(skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSequencedSigner(local, 10)
+	var sigs []string
+	for want := uint64(10); want < 13; want++ {
+		sig, seq, err := s.SignNext([]byte("tick"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq != want {
+			t.Fatalf("SignNext gave sequence %d, want %d", seq, want)
+		}
+		sigs = append(sigs, sig)
+	}
+	for i, sig := range sigs {
+		if ok, err := VerifySequenced(pubKeyHex, sig, []byte("tick"), uint64(10+i)); err != nil || !ok {
+			t.Fatalf("signature %d at its own sequence: %v, %v; want true", i, ok, err)
+		}
+	}
+	// Presenting the third signature as the second, or replaying the first
+	// later, is detected.
+	if ok, err := VerifySequenced(pubKeyHex, sigs[2], []byte("tick"), 11); err != nil || ok {
+		t.Fatalf("reordered signature: %v, %v; want false", ok, err)
+	}
+	if ok, err := VerifySequenced(pubKeyHex, sigs[0], []byte("tick"), 13); err != nil || ok {
+		t.Fatalf("replayed signature: %v, %v; want false", ok, err)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+)
+
+var (
+	ErrMissingEnvKey   = errors.New("secret key environment variable not set")
+	ErrMessageTooLarge = errors.New("message exceeds the maximum message size")
+)
+
+// maxMessageBytes is read on every sign and verify, possibly concurrently
+// with SetMaxMessageBytes, so it is atomic.
+var maxMessageBytes atomic.Int64
+
+// SetMaxMessageBytes caps the message size GenerateSignature and
+// VerifySignature will hash. Zero, the default, means unlimited; negative
+// values are treated as zero. It is safe to call while signing or verifying.
+func SetMaxMessageBytes(n int) {
+	maxMessageBytes.Store(int64(max(n, 0)))
+}
+
+// MaxMessageBytes returns the limit set by SetMaxMessageBytes.
+func MaxMessageBytes() int {
+	return int(maxMessageBytes.Load())
+}
+
+func checkMessageSize(n int) error {
+	if limit := MaxMessageBytes(); limit > 0 && n > limit {
+		return fmt.Errorf("%w: %d > %d bytes", ErrMessageTooLarge, n, limit)
+	}
+	return nil
+}
+
+// signingRoot hashes msg to the 32 bytes that are actually signed, so messages
+// of any length can be signed and verified.
+func signingRoot(msg []byte) [32]byte {
+	return sha256.Sum256(msg)
+}
+
+// BuildSigningInput hashes fields into a 32-byte signing root, prefixing each
+// with its 8-byte big-endian length so that different splits of the same bytes,
+// such as ("ab", "c") and ("a", "bc"), never collide.
+func BuildSigningInput(fields ...[]byte) [32]byte {
+	h := sha256.New()
+	var n [8]byte
+	for _, f := range fields {
+		binary.BigEndian.PutUint64(n[:], uint64(len(f)))
+		h.Write(n[:])
+		h.Write(f)
+	}
+	var root [32]byte
+	h.Sum(root[:0])
+	return root
+}
+
+// GenerateSignature signs the SHA-256 of msg with the hex-encoded secret key.
+func GenerateSignature(skHex string, msg []byte) (string, error) {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return "", err
+	}
+	return signRoot(skHex, signingRoot(msg))
+}
+
+// signRoot signs an already computed 32-byte signing root.
+func signRoot(skHex string, root [32]byte) (string, error) {
+	sk, err := secretKeyFromHex(skHex)
+	if err != nil {
+		return "", err
+	}
+	return EncodingEthereum.Encode(sk.Sign(root[:]).Marshal()), nil
+}
+
+// SignFromEnv signs msg with the secret key hex held in the environment
+// variable envVar. The key is never returned, logged or echoed in errors.
+func SignFromEnv(envVar string, msg []byte) (string, error) {
+	skHex, ok := os.LookupEnv(envVar)
+	if !ok || skHex == "" {
+		return "", fmt.Errorf("%w: %s", ErrMissingEnvKey, envVar)
+	}
+	return GenerateSignature(skHex, msg)
+}
+
+// SignBothForms signs msg like GenerateSignature and returns the signature in
+// both its compressed (96-byte) and uncompressed (192-byte) encodings.
+func SignBothForms(skHex string, msg []byte) (compressedHex, uncompressedHex string, err error) {
+	compressedHex, err = GenerateSignature(skHex, msg)
+	if err != nil {
+		return "", "", err
+	}
+	b, err := decodeHex(compressedHex)
+	if err != nil {
+		return "", "", err
+	}
+	p, err := decodeSignaturePoint(b)
+	if err != nil {
+		return "", "", err
+	}
+	return compressedHex, EncodingEthereum.Encode(p.Serialize()), nil
+}
+
+// SignManyKeysOneMessage hashes msg once and signs the result with every key
+// in skHexes, returning the signatures in key order. Only the SHA-256 signing
+// root is shared: hashing it to G2 still happens inside each key's Sign, so
+// BenchmarkSignManyKeysOneMessage puts it within noise of calling
+// GenerateSignature per key (about 0.6ms per key either way).
+func SignManyKeysOneMessage(skHexes []string, msg []byte) ([]string, error) {
+	sigs, _, err := signManyKeys(skHexes, msg, false)
+	return sigs, err
+}
+
+// SignManyKeysOneMessageAggregate is SignManyKeysOneMessage that also returns
+// the aggregate of the signatures.
+func SignManyKeysOneMessageAggregate(skHexes []string, msg []byte) ([]string, string, error) {
+	return signManyKeys(skHexes, msg, true)
+}
+
+func signManyKeys(skHexes []string, msg []byte, aggregate bool) ([]string, string, error) {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return nil, "", err
+	}
+	root := signingRoot(msg)
+	sigs := make([]string, len(skHexes))
+	var raw []common.Signature
+	for i, skHex := range skHexes {
+		sk, err := secretKeyFromHex(skHex)
+		if err != nil {
+			return nil, "", fmt.Errorf("secret key %d: %w", i, err)
+		}
+		sig := sk.Sign(root[:])
+		sigs[i] = EncodingEthereum.Encode(sig.Marshal())
+		if aggregate {
+			raw = append(raw, sig)
+		}
+	}
+	if !aggregate {
+		return sigs, "", nil
+	}
+	if len(raw) == 0 {
+		return nil, "", ErrNoSignatures
+	}
+	return sigs, EncodingEthereum.Encode(bls.AggregateSignatures(raw).Marshal()), nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+func TestSignFromEnv(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	t.Setenv("BLS_SIG_TEST_KEY", skHex)
+	sig, err := SignFromEnv("BLS_SIG_TEST_KEY", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := VerifySignature(pubKeyHex, sig, "hello")
+	if err != nil || !ok {
+		t.Fatalf("VerifySignature = %v, %v; want true", ok, err)
+	}
+
+	if _, err := SignFromEnv("BLS_SIG_TEST_KEY_UNSET", []byte("hello")); !errors.Is(err, ErrMissingEnvKey) {
+		t.Fatalf("unset variable: err = %v, want ErrMissingEnvKey", err)
+	}
+}
+
+func TestSignBothForms(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	compressedHex, uncompressedHex, err := SignBothForms(skHex, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed, _ := decodeHex(compressedHex)
+	uncompressed, _ := decodeHex(uncompressedHex)
+	if len(compressed) != SignatureLength || len(uncompressed) != 2*SignatureLength {
+		t.Fatalf("lengths %d and %d, want %d and %d", len(compressed), len(uncompressed), SignatureLength, 2*SignatureLength)
+	}
+	p1, err := decodeSignaturePoint(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := decodeSignaturePoint(uncompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p1.Equals(p2) {
+		t.Fatal("forms decode to different points")
+	}
+	for name, p := range map[string]*blst.P2Affine{"compressed": p1, "uncompressed": p2} {
+		ok, err := VerifySignature(pubKeyHex, EncodingEthereum.Encode(p.Compress()), "hello")
+		if err != nil || !ok {
+			t.Fatalf("%s form: %v, %v; want true", name, ok, err)
+		}
+	}
+}
+
+func TestMaxMessageBytes(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	SetMaxMessageBytes(8)
+	t.Cleanup(func() { SetMaxMessageBytes(0) })
+
+	sig, err := GenerateSignature(skHex, []byte("12345678"))
+	if err != nil {
+		t.Fatalf("message at the limit: %v", err)
+	}
+	if _, err := GenerateSignature(skHex, []byte("123456789")); !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("sign over the limit: err = %v, want ErrMessageTooLarge", err)
+	}
+	if _, err := VerifySignature(pubKeyHex, sig, "123456789"); !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("verify over the limit: err = %v, want ErrMessageTooLarge", err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, sig, "12345678"); err != nil || !ok {
+		t.Fatalf("verify at the limit: %v, %v; want true", ok, err)
+	}
+
+	SetMaxMessageBytes(0)
+	if _, err := GenerateSignature(skHex, make([]byte, 1<<16)); err != nil {
+		t.Fatalf("unlimited: %v", err)
+	}
+}
+
+func TestSignManyKeysOneMessage(t *testing.T) {
+	sks, pks, want := testSigners(t, 3, "duty")
+	sigs, agg, err := SignManyKeysOneMessageAggregate(sks, []byte("duty"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sigs, want) {
+		t.Fatalf("signatures differ from per-key GenerateSignature")
+	}
+	if ok, err := FastAggregateVerify(agg, "duty", pks); err != nil || !ok {
+		t.Fatalf("aggregate: %v, %v; want valid", ok, err)
+	}
+}
+
+func benchmarkKeys(b *testing.B, n int) []string {
+	b.Helper()
+	sks := make([]string, n)
+	for i := range sks {
+		sk, _, err := GenerateKeyPair()
+		if err != nil {
+			b.Fatal(err)
+		}
+		sks[i] = sk
+	}
+	return sks
+}
+
+func BenchmarkSignManyKeysOneMessage(b *testing.B) {
+	sks := benchmarkKeys(b, 64)
+	msg := make([]byte, 4096)
+	b.Run("SignManyKeysOneMessage", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := SignManyKeysOneMessage(sks, msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("GenerateSignature", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, sk := range sks {
+				if _, err := GenerateSignature(sk, msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+func TestBuildSigningInputLengthPrefixed(t *testing.T) {
+	a := BuildSigningInput([]byte("ab"), []byte("c"))
+	b := BuildSigningInput([]byte("a"), []byte("bc"))
+	if a == b {
+		t.Fatal(`("ab","c") and ("a","bc") produce the same signing input`)
+	}
+	if BuildSigningInput([]byte("abc")) == BuildSigningInput([]byte("abc"), nil) {
+		t.Fatal("a trailing empty field does not change the signing input")
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var (
+	ErrInvalidSignature = errors.New("could not decode signature")
+	ErrTrailingBytes    = errors.New("signature has trailing bytes")
+	ErrNonCanonical     = errors.New("signature is not canonically encoded")
+)
+
+// rejectNonCanonical holds the setting of SetRejectNonCanonicalSignatures.
+var rejectNonCanonical atomic.Bool
+
+// SetRejectNonCanonicalSignatures makes signature decoding fail with
+// ErrNonCanonical unless the input is the compressed encoding the point
+// itself would serialize to. It is off by default and safe to change while
+// verifying.
+func SetRejectNonCanonicalSignatures(reject bool) {
+	rejectNonCanonical.Store(reject)
+}
+
+// RejectNonCanonicalSignatures reports the setting of
+// SetRejectNonCanonicalSignatures.
+func RejectNonCanonicalSignatures() bool {
+	return rejectNonCanonical.Load()
+}
+
+// SignatureFromBytes decodes a compressed signature, rejecting input that is
+// not exactly 96 bytes before it reaches the backend.
+func SignatureFromBytes(b []byte) (common.Signature, error) {
+	if err := checkSignatureLength(b, SignatureLength); err != nil {
+		return nil, err
+	}
+	if RejectNonCanonicalSignatures() {
+		if _, err := decodeSignaturePoint(b); err != nil {
+			return nil, err
+		}
+	}
+	return bls.SignatureFromBytes(b)
+}
+
+func checkSignatureLength(b []byte, want int) error {
+	switch {
+	case len(b) > want:
+		return fmt.Errorf("%w: signature is %d bytes, want %d", ErrTrailingBytes, len(b), want)
+	case len(b) < want:
+		return fmt.Errorf("%w: signature is %d bytes, want %d", ErrInvalidSignature, len(b), want)
+	}
+	return nil
+}
+
+// decodeSignaturePoint parses a compressed (96-byte) or uncompressed (192-byte)
+// G2 signature and checks that it lies in the subgroup, applying
+// SetRejectNonCanonicalSignatures if set.
+func decodeSignaturePoint(b []byte) (*blst.P2Affine, error) {
+	p, err := parseSignaturePoint(b)
+	if err != nil {
+		return nil, err
+	}
+	if RejectNonCanonicalSignatures() && !bytes.Equal(p.Compress(), b) {
+		return nil, ErrNonCanonical
+	}
+	return p, nil
+}
+
+func parseSignaturePoint(b []byte) (*blst.P2Affine, error) {
+	var p *blst.P2Affine
+	switch len(b) {
+	case blst.BLST_P2_COMPRESS_BYTES:
+		p = new(blst.P2Affine).Uncompress(b)
+	case blst.BLST_P2_SERIALIZE_BYTES:
+		p = new(blst.P2Affine).Deserialize(b)
+	default:
+		sentinel := ErrInvalidSignature
+		if len(b) > blst.BLST_P2_COMPRESS_BYTES {
+			sentinel = ErrTrailingBytes
+		}
+		return nil, fmt.Errorf("%w: signature is %d bytes, want %d or %d", sentinel,
+			len(b), blst.BLST_P2_COMPRESS_BYTES, blst.BLST_P2_SERIALIZE_BYTES)
+	}
+	if p == nil || !p.SigValidate(false) {
+		return nil, ErrInvalidSignature
+	}
+	return p, nil
+}
+
+// IsCanonicalSignature reports whether sigHex is exactly the compressed
+// encoding of the point it decodes to. Uncompressed or otherwise re-encodable
+// input decodes but is not canonical.
+func IsCanonicalSignature(sigHex string) (bool, error) {
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return false, fmt.Errorf("signature: %w", err)
+	}
+	p, err := parseSignaturePoint(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(p.Compress(), b), nil
+}
+
+// CompareSignatures reports whether two hex signatures are byte-identical. On a
+// mismatch the detail string says whether they still encode the same point.
+func CompareSignatures(ourSigHex, theirSigHex string) (bool, string, error) {
+	ours, err := decodeHex(ourSigHex)
+	if err != nil {
+		return false, "", fmt.Errorf("our signature: %w", err)
+	}
+	theirs, err := decodeHex(theirSigHex)
+	if err != nil {
+		return false, "", fmt.Errorf("their signature: %w", err)
+	}
+	if bytes.Equal(ours, theirs) {
+		return true, "identical bytes", nil
+	}
+
+	ourPoint, err := parseSignaturePoint(ours)
+	if err != nil {
+		return false, "", fmt.Errorf("our signature: %w", err)
+	}
+	theirPoint, err := parseSignaturePoint(theirs)
+	if err != nil {
+		return false, "", fmt.Errorf("their signature: %w", err)
+	}
+	if ourPoint.Equals(theirPoint) {
+		return false, "same point, different encoding", nil
+	}
+	return false, "different signatures", nil
+}
+
+// AuditSignatureStructure looks for degenerate structure in a signature from
+// an untrusted source and returns a warning for each problem found; a
+// well-formed signature gives none. It checks encoding only, not validity
+// against any key or message.
+func AuditSignatureStructure(sigHex string) ([]string, error) {
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	var warnings []string
+	if len(b) != blst.BLST_P2_COMPRESS_BYTES && len(b) != blst.BLST_P2_SERIALIZE_BYTES {
+		warnings = append(warnings, fmt.Sprintf("length %d is neither %d (compressed) nor %d (uncompressed)",
+			len(b), blst.BLST_P2_COMPRESS_BYTES, blst.BLST_P2_SERIALIZE_BYTES))
+	}
+	switch {
+	case len(b) > 0 && allBytes(b, 0x00):
+		warnings = append(warnings, "all-zero encoding")
+	case len(b) > 0 && allBytes(b, 0xff):
+		warnings = append(warnings, "all-one encoding")
+	}
+	if len(warnings) > 0 {
+		return warnings, nil
+	}
+
+	var p *blst.P2Affine
+	if len(b) == blst.BLST_P2_COMPRESS_BYTES {
+		p = new(blst.P2Affine).Uncompress(b)
+	} else {
+		p = new(blst.P2Affine).Deserialize(b)
+	}
+	switch {
+	case p == nil:
+		warnings = append(warnings, "does not decode to a point on the curve")
+	case p.Equals(new(blst.P2Affine)):
+		warnings = append(warnings, "point at infinity")
+	case !p.InG2():
+		warnings = append(warnings, "point is not in the prime-order subgroup (low-order component)")
+	case len(b) == blst.BLST_P2_COMPRESS_BYTES && !bytes.Equal(p.Compress(), b):
+		warnings = append(warnings, "non-canonical encoding")
+	}
+	return warnings, nil
+}
+
+func allBytes(b []byte, v byte) bool {
+	for _, c := range b {
+		if c != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+func TestCompareSignatures(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	sig := mustSign(t, skHex, "hello")
+	other := mustSign(t, skHex, "goodbye")
+
+	b, err := decodeHex(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed := EncodingEthereum.Encode(new(blst.P2Affine).Uncompress(b).Serialize())
+
+	tests := []struct {
+		name   string
+		theirs string
+		equal  bool
+		detail string
+	}{
+		{"identical", sig, true, "identical bytes"},
+		{"re-encoded", uncompressed, false, "same point, different encoding"},
+		{"different", other, false, "different signatures"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, detail, err := CompareSignatures(sig, tt.theirs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if equal != tt.equal || detail != tt.detail {
+				t.Fatalf("CompareSignatures = %v, %q; want %v, %q", equal, detail, tt.equal, tt.detail)
+			}
+		})
+	}
+}
+
+func TestIsCanonicalSignature(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "hello")
+	b, _ := decodeHex(sig)
+	uncompressed := new(blst.P2Affine).Uncompress(b).Serialize()
+
+	if ok, err := IsCanonicalSignature(sig); err != nil || !ok {
+		t.Fatalf("compressed: %v, %v; want canonical", ok, err)
+	}
+	if ok, err := IsCanonicalSignature(EncodingEthereum.Encode(uncompressed)); err != nil || ok {
+		t.Fatalf("uncompressed: %v, %v; want non-canonical", ok, err)
+	}
+
+	// VerifyTagged decodes with decodeSignaturePoint, which accepts the
+	// uncompressed form unless non-canonical input is rejected.
+	tagged := EncodingEthereum.Encode(append([]byte{byte(SchemePOP)}, uncompressed...))
+	if ok, err := VerifyTagged(pubKeyHex, tagged, "hello"); err != nil || !ok {
+		t.Fatalf("lax verification: %v, %v; want true", ok, err)
+	}
+	SetRejectNonCanonicalSignatures(true)
+	t.Cleanup(func() { SetRejectNonCanonicalSignatures(false) })
+	if _, err := VerifyTagged(pubKeyHex, tagged, "hello"); !errors.Is(err, ErrNonCanonical) {
+		t.Fatalf("strict verification: err = %v, want ErrNonCanonical", err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, sig, "hello"); err != nil || !ok {
+		t.Fatalf("strict verification of canonical input: %v, %v; want true", ok, err)
+	}
+}
+
+func TestSignatureTrailingBytes(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "hello")
+	b, err := decodeHex(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	long := append(append([]byte(nil), b...), 0x00)
+
+	if _, err := SignatureFromBytes(long); !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("SignatureFromBytes(97 bytes): err = %v, want ErrTrailingBytes", err)
+	}
+	if _, err := VerifySignature(pubKeyHex, EncodingEthereum.Encode(long), "hello"); !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("VerifySignature(97 bytes): err = %v, want ErrTrailingBytes", err)
+	}
+	_, err = decodeSignaturePoint(long)
+	if !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("decodeSignaturePoint(97 bytes): err = %v, want ErrTrailingBytes", err)
+	}
+	if !strings.Contains(err.Error(), "want 96 or 192") {
+		t.Fatalf("error %q does not name both accepted lengths", err)
+	}
+}
+
+// offSubgroupSignature returns a compressed G2 encoding of a point on the
+// curve but outside the prime-order subgroup. G2's cofactor is so large that
+// the first small x with a curve point is all but certain to qualify.
+func offSubgroupSignature(t *testing.T) []byte {
+	t.Helper()
+	for k := 1; k < 256; k++ {
+		b := make([]byte, blst.BLST_P2_COMPRESS_BYTES)
+		b[0] = 0x80 // compressed flag
+		b[len(b)-1] = byte(k)
+		if p := new(blst.P2Affine).Uncompress(b); p != nil && !p.InG2() {
+			return b
+		}
+	}
+	t.Fatal("no off-subgroup point found")
+	return nil
+}
+
+func TestAuditSignatureStructure(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	warnings, err := AuditSignatureStructure(mustSign(t, skHex, "hello"))
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("normal signature: warnings=%v err=%v", warnings, err)
+	}
+
+	crafted := EncodingEthereum.Encode(offSubgroupSignature(t))
+	warnings, err = AuditSignatureStructure(crafted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "prime-order subgroup") {
+		t.Fatalf("off-subgroup point: warnings = %v", warnings)
+	}
+
+	warnings, err = AuditSignatureStructure(EncodingEthereum.Encode(make([]byte, blst.BLST_P2_COMPRESS_BYTES)))
+	if err != nil || len(warnings) != 1 || warnings[0] != "all-zero encoding" {
+		t.Fatalf("all-zero: warnings=%v err=%v", warnings, err)
+	}
+}
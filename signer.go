@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+)
+
+// Signer produces signatures without exposing where the secret key lives. An
+// HSM or remote KMS backend implements it the same way LocalSigner does;
+// aggregation and verification never need a Signer.
+type Signer interface {
+	// PublicKey returns the signer's public key as hex.
+	PublicKey() (string, error)
+	// Sign signs msg the same way GenerateSignature does and returns hex.
+	Sign(msg []byte) (string, error)
+}
+
+// LocalSigner is a Signer backed by an in-memory secret key.
+type LocalSigner struct {
+	sk common.SecretKey
+}
+
+var _ Signer = (*LocalSigner)(nil)
+
+// NewLocalSigner decodes skHex into a LocalSigner.
+func NewLocalSigner(skHex string) (*LocalSigner, error) {
+	sk, err := secretKeyFromHex(skHex)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalSigner{sk: sk}, nil
+}
+
+// PublicKey implements Signer.
+func (s *LocalSigner) PublicKey() (string, error) {
+	return EncodingEthereum.Encode(s.sk.PublicKey().Marshal()), nil
+}
+
+// Sign implements Signer.
+func (s *LocalSigner) Sign(msg []byte) (string, error) {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return "", err
+	}
+	root := signingRoot(msg)
+	return EncodingEthereum.Encode(s.sk.Sign(root[:]).Marshal()), nil
+}
+
+// PreparedSigner is a LocalSigner used to sign many messages with one key: the
+// hex key is decoded once instead of on every GenerateSignature call. Signing
+// is dominated by hashing to G2 and the scalar multiplication, though: over
+// 100k messages BenchmarkPreparedSigner and BenchmarkGenerateSignature both
+// measure about 0.7ms per signature, the decode being lost in the noise.
+type PreparedSigner = LocalSigner
+
+// NewPreparedSigner decodes skHex once for repeated signing.
+func NewPreparedSigner(skHex string) (*PreparedSigner, error) {
+	return NewLocalSigner(skHex)
+}
+
+var ErrManifestMismatch = errors.New("manifest does not match this signer's configuration")
+
+// Manifest describes how a signer produces signatures, so two parties can
+// confirm they are configured identically before exchanging signatures.
+type Manifest struct {
+	Scheme          string `json:"scheme"`
+	DST             string `json:"dst"`
+	Encoding        string `json:"encoding"`
+	Hash            string `json:"hash"`
+	PubKeyLength    int    `json:"pubkey_length"`
+	SignatureLength int    `json:"signature_length"`
+	SecretKeyLength int    `json:"secret_key_length"`
+}
+
+// localManifest is the configuration every LocalSigner uses: the
+// proof-of-possession scheme over the SHA-256 of the message, 0x hex output.
+func localManifest() Manifest {
+	dst, _ := SchemePOP.DST()
+	return Manifest{
+		Scheme:          "pop",
+		DST:             string(dst),
+		Encoding:        "0x-hex",
+		Hash:            "sha256",
+		PubKeyLength:    PubKeyLength,
+		SignatureLength: SignatureLength,
+		SecretKeyLength: SecretKeyLength,
+	}
+}
+
+// Manifest returns the signer's configuration.
+func (s *LocalSigner) Manifest() Manifest {
+	return localManifest()
+}
+
+// NewSignerFromManifest returns a LocalSigner for skHex after checking that m
+// describes the configuration LocalSigner uses, returning ErrManifestMismatch
+// otherwise. The manifest carries no key material.
+func NewSignerFromManifest(m Manifest, skHex string) (*LocalSigner, error) {
+	if m != localManifest() {
+		return nil, ErrManifestMismatch
+	}
+	return NewLocalSigner(skHex)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// mockHSM is a Signer standing in for a remote backend: it only ever hands
+// out signatures and counts the requests it served.
+type mockHSM struct {
+	skHex, pubKeyHex string
+	calls            int
+}
+
+func (m *mockHSM) PublicKey() (string, error) {
+	return m.pubKeyHex, nil
+}
+
+func (m *mockHSM) Sign(msg []byte) (string, error) {
+	m.calls++
+	return GenerateSignature(m.skHex, msg)
+}
+
+func TestSignerInterface(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	local, err := NewLocalSigner(skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hsm := &mockHSM{skHex: skHex, pubKeyHex: pubKeyHex}
+
+	for _, s := range []Signer{local, hsm} {
+		pk, err := s.PublicKey()
+		if err != nil || pk != pubKeyHex {
+			t.Fatalf("%T.PublicKey() = %s, %v; want %s", s, pk, err, pubKeyHex)
+		}
+		sig, err := s.Sign([]byte("payload"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := VerifySignature(pk, sig, "payload"); err != nil || !ok {
+			t.Fatalf("%T signature: %v, %v; want valid", s, ok, err)
+		}
+	}
+	if hsm.calls != 1 {
+		t.Fatalf("mock served %d signatures, want 1", hsm.calls)
+	}
+}
+
+func TestPreparedSignerMatchesGenerateSignature(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	ps, err := NewPreparedSigner(skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		msg := "message " + strconv.Itoa(i)
+		got, err := ps.Sign([]byte(msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := mustSign(t, skHex, msg); got != want {
+			t.Fatalf("%q: prepared %s, one-shot %s", msg, got, want)
+		}
+	}
+}
+
+// benchmarkMessages returns distinct messages for the signing benchmarks;
+// b.N cycles through them.
+func benchmarkMessages() [][]byte {
+	msgs := make([][]byte, 1024)
+	for i := range msgs {
+		msgs[i] = []byte("attestation " + strconv.Itoa(i))
+	}
+	return msgs
+}
+
+func BenchmarkPreparedSigner(b *testing.B) {
+	skHex, _ := ExampleKeyPair()
+	ps, err := NewPreparedSigner(skHex)
+	if err != nil {
+		b.Fatal(err)
+	}
+	msgs := benchmarkMessages()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ps.Sign(msgs[i%len(msgs)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateSignature(b *testing.B) {
+	skHex, _ := ExampleKeyPair()
+	msgs := benchmarkMessages()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GenerateSignature(skHex, msgs[i%len(msgs)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestManifestJSONRoundTrip(t *testing.T) {
+	skHex, _ := testKeyPair(t)
+	s, err := NewLocalSigner(skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(s.Manifest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	peer, err := NewSignerFromManifest(m, skHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peer.Manifest() != s.Manifest() {
+		t.Fatalf("round-tripped manifest %+v, want %+v", peer.Manifest(), s.Manifest())
+	}
+
+	m.Scheme = "basic"
+	if _, err := NewSignerFromManifest(m, skHex); !errors.Is(err, ErrManifestMismatch) {
+		t.Fatalf("different scheme: err = %v, want ErrManifestMismatch", err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SQLRegistrySchema creates the table SQLRegistry queries.
+const SQLRegistrySchema = `CREATE TABLE IF NOT EXISTS validators (
+	idx    INTEGER PRIMARY KEY,
+	alias  TEXT UNIQUE,
+	pubkey TEXT NOT NULL
+)`
+
+// SQLRegistry is a KeyRegistry that looks public keys up in a database, for
+// registries too large to load from JSON. Its queries are written for SQLite:
+// they use ? placeholders and Put uses INSERT ... ON CONFLICT, so other
+// databases may need them adapted. The caller opens db and keeps ownership of
+// it.
+type SQLRegistry struct {
+	db *sql.DB
+}
+
+// NewSQLRegistry returns a registry over db, creating the validators table if
+// it doesn't exist.
+func NewSQLRegistry(db *sql.DB) (*SQLRegistry, error) {
+	if _, err := db.Exec(SQLRegistrySchema); err != nil {
+		return nil, fmt.Errorf("create registry table: %w", err)
+	}
+	return &SQLRegistry{db: db}, nil
+}
+
+// Put stores pubKeyHex, in canonical compressed form, under index and, if
+// alias is not empty, alias, replacing any existing entry for index. An alias
+// already held by another index is an error.
+func (r *SQLRegistry) Put(index uint64, alias, pubKeyHex string) error {
+	b, err := decodeHex(pubKeyHex)
+	if err == nil {
+		b, err = canonicalPubKeyBytes(b)
+	}
+	if err != nil {
+		return fmt.Errorf("index %d: %w", index, err)
+	}
+	var a sql.NullString
+	if alias != "" {
+		a = sql.NullString{String: alias, Valid: true}
+	}
+	_, err = r.db.Exec(`INSERT INTO validators (idx, alias, pubkey) VALUES (?, ?, ?)
+		ON CONFLICT (idx) DO UPDATE SET alias = excluded.alias, pubkey = excluded.pubkey`,
+		int64(index), a, EncodingEthereum.Encode(b))
+	return err
+}
+
+// VerifyByIndices is Registry.VerifyByIndices with keys read from the
+// database. An index with no row returns ErrUnknownIndex.
+func (r *SQLRegistry) VerifyByIndices(aggSigHex, msg string, indices []uint64) (bool, error) {
+	if len(indices) == 0 {
+		return false, ErrNoPublicKeys
+	}
+	pks := make([]string, len(indices))
+	for i, idx := range indices {
+		pk, err := r.lookup(`SELECT pubkey FROM validators WHERE idx = ?`, int64(idx))
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("%w: %d", ErrUnknownIndex, idx)
+		}
+		if err != nil {
+			return false, err
+		}
+		pks[i] = pk
+	}
+	return FastAggregateVerify(aggSigHex, msg, pks)
+}
+
+// VerifyByAlias is Registry.VerifyByAlias with keys read from the database. An alias
+// with no row returns ErrUnknownAlias.
+func (r *SQLRegistry) VerifyByAlias(aggSigHex, msg string, aliases []string) (bool, error) {
+	pks := make([]string, len(aliases))
+	for i, a := range aliases {
+		pk, err := r.lookup(`SELECT pubkey FROM validators WHERE alias = ?`, a)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("%w: %q", ErrUnknownAlias, a)
+		}
+		if err != nil {
+			return false, err
+		}
+		pks[i] = pk
+	}
+	return FastAggregateVerify(aggSigHex, msg, pks)
+}
+
+func (r *SQLRegistry) lookup(query string, arg any) (string, error) {
+	var pk string
+	err := r.db.QueryRow(query, arg).Scan(&pk)
+	return pk, err
+}
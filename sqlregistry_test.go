@@ -0,0 +1,63 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSQLRegistry(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// Each connection to :memory: is its own database.
+	db.SetMaxOpenConns(1)
+	r, err := NewSQLRegistry(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const msg = "epoch 5"
+	_, pks, sigs := testSigners(t, 3, msg)
+	for i, alias := range []string{"alice", "bob", ""} {
+		if err := r.Put(uint64(i), alias, pks[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	agg := mustAggregate(t, sigs[:2])
+
+	if ok, err := r.VerifyByIndices(agg, msg, []uint64{0, 1}); err != nil || !ok {
+		t.Fatalf("indices 0, 1: ok=%v err=%v", ok, err)
+	}
+	if ok, err := r.VerifyByAlias(agg, msg, []string{"bob", "alice"}); err != nil || !ok {
+		t.Fatalf("aliases: ok=%v err=%v", ok, err)
+	}
+	if _, err := r.VerifyByIndices(agg, msg, []uint64{0, 7}); !errors.Is(err, ErrUnknownIndex) {
+		t.Fatalf("unknown index: err = %v, want ErrUnknownIndex", err)
+	}
+	if _, err := r.VerifyByAlias(agg, msg, []string{"alice", "carol"}); !errors.Is(err, ErrUnknownAlias) {
+		t.Fatalf("unknown alias: err = %v, want ErrUnknownAlias", err)
+	}
+
+	// Put replaces the entry for an index, and another index cannot take an
+	// alias already in use.
+	if err := r.Put(1, "bob", pks[2]); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := r.VerifyByIndices(agg, msg, []uint64{0, 1}); err != nil || ok {
+		t.Fatalf("after replacing index 1: ok=%v err=%v", ok, err)
+	}
+	if err := r.Put(2, "alice", pks[2]); err == nil {
+		t.Fatal("second validator took alias alice")
+	}
+	if ok, err := r.VerifyByAlias(agg, msg, []string{"alice"}); err != nil || ok {
+		t.Fatalf("alice after rejected Put: ok=%v err=%v", ok, err)
+	}
+	if ok, err := r.VerifyByAlias(mustAggregate(t, sigs[:1]), msg, []string{"alice"}); err != nil || !ok {
+		t.Fatalf("alice kept her key: ok=%v err=%v", ok, err)
+	}
+}
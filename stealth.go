@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var ErrZeroTweak = errors.New("tweak reduces to zero")
+
+// curveOrder is r, the order of the BLS12-381 G1 and G2 subgroups.
+var curveOrder, _ = new(big.Int).SetString(bls.CurveOrder, 10)
+
+// tweakScalar reads tweak as a big-endian integer reduced modulo r.
+func tweakScalar(tweak []byte) (*big.Int, error) {
+	t := new(big.Int).SetBytes(tweak)
+	t.Mod(t, curveOrder)
+	if t.Sign() == 0 {
+		return nil, ErrZeroTweak
+	}
+	return t, nil
+}
+
+// DeriveStealthPubKey returns the one-time public key base + tweak*G. It needs
+// no secret key; the holder of the base secret key derives the matching secret
+// with DeriveStealthSecret.
+func DeriveStealthPubKey(basePubKeyHex string, tweak []byte) (string, error) {
+	base, err := blstPublicKeyFromHex(basePubKeyHex)
+	if err != nil {
+		return "", err
+	}
+	t, err := tweakScalar(tweak)
+	if err != nil {
+		return "", err
+	}
+	s := new(blst.Scalar).Deserialize(t.FillBytes(make([]byte, 32)))
+	p := blst.P1Generator().Mult(s)
+	p.AddAssign(base)
+	out := p.ToAffine()
+	if !out.KeyValidate() {
+		return "", ErrInvalidPublicKey
+	}
+	return EncodingEthereum.Encode(out.Compress()), nil
+}
+
+// DeriveStealthSecret returns (baseSk + tweak) mod r, the secret key for the
+// public key DeriveStealthPubKey derives from the same tweak.
+func DeriveStealthSecret(baseSkHex string, tweak []byte) (string, error) {
+	if _, err := secretKeyFromHex(baseSkHex); err != nil {
+		return "", err
+	}
+	b, err := decodeHex(baseSkHex)
+	if err != nil {
+		return "", ErrInvalidSecretKey
+	}
+	defer zeroize(b)
+	t, err := tweakScalar(tweak)
+	if err != nil {
+		return "", err
+	}
+	sum := new(big.Int).SetBytes(b)
+	sum.Add(sum, t)
+	sum.Mod(sum, curveOrder)
+	out := sum.FillBytes(make([]byte, 32))
+	defer zeroize(out)
+	sk, err := bls.SecretKeyFromBytes(out)
+	if err != nil {
+		return "", err
+	}
+	return EncodingEthereum.Encode(sk.Marshal()), nil
+}
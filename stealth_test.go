@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStealthSecretMatchesStealthPubKey(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	tweak := []byte("shared secret from key exchange")
+	stealthPk, err := DeriveStealthPubKey(pubKeyHex, tweak)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stealthSk, err := DeriveStealthSecret(skHex, tweak)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mustPubKey(t, stealthSk); got != stealthPk {
+		t.Fatalf("derived secret's public key %s, want %s", got, stealthPk)
+	}
+	if stealthPk == pubKeyHex {
+		t.Fatal("stealth key equals the base key")
+	}
+	if ok, err := VerifySignature(stealthPk, mustSign(t, stealthSk, "pay"), "pay"); err != nil || !ok {
+		t.Fatalf("stealth signature: %v, %v; want true", ok, err)
+	}
+
+	zero := curveOrder.FillBytes(make([]byte, 32))
+	if _, err := DeriveStealthPubKey(pubKeyHex, zero); !errors.Is(err, ErrZeroTweak) {
+		t.Fatalf("tweak of r: err = %v, want ErrZeroTweak", err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// VerifyStreamCSV verifies entries in order and writes one CSV row per entry
+// (index,pubkey,valid,duration_ms) as soon as it completes, after a header
+// row. Each row is flushed to w so long jobs show progress. A malformed entry
+// is written as invalid.
+func VerifyStreamCSV(entries []VerifyTuple, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := writeCSVRow(cw, "index", "pubkey", "valid", "duration_ms"); err != nil {
+		return err
+	}
+	for i, e := range entries {
+		start := time.Now()
+		ok, err := VerifySignature(e.PubKey, e.Signature, e.Message)
+		ms := float64(time.Since(start).Microseconds()) / 1000
+		row := []string{
+			strconv.Itoa(i),
+			e.PubKey,
+			strconv.FormatBool(err == nil && ok),
+			strconv.FormatFloat(ms, 'f', 3, 64),
+		}
+		if err := writeCSVRow(cw, row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSVRow(cw *csv.Writer, fields ...string) error {
+	if err := cw.Write(fields); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+)
+
+// countingWriter counts the writes that reach it.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestVerifyStreamCSV(t *testing.T) {
+	entries := testTuples(t, 3)
+	entries[1].Message = "tampered"
+	var w countingWriter
+	if err := VerifyStreamCSV(entries, &w); err != nil {
+		t.Fatal(err)
+	}
+	if w.writes != len(entries)+1 {
+		t.Fatalf("%d writes, want one per row (%d)", w.writes, len(entries)+1)
+	}
+	rows, err := csv.NewReader(&w.Buffer).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rows[0]; len(got) != 4 || got[0] != "index" || got[3] != "duration_ms" {
+		t.Fatalf("header %v", got)
+	}
+	for i, row := range rows[1:] {
+		if row[0] != strconv.Itoa(i) || row[1] != entries[i].PubKey {
+			t.Fatalf("row %d = %v, out of order", i, row)
+		}
+		if want := strconv.FormatBool(i != 1); row[2] != want {
+			t.Fatalf("row %d valid = %s, want %s", i, row[2], want)
+		}
+	}
+}
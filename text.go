@@ -0,0 +1,16 @@
+package main
+
+import "golang.org/x/text/unicode/norm"
+
+// SignText signs the NFC normalisation of text, so that composed and
+// decomposed spellings of the same characters give the same signature. Only
+// SignText and VerifyText normalise; GenerateSignature and VerifySignature
+// sign and check the bytes they are given.
+func SignText(skHex string, text string) (string, error) {
+	return GenerateSignature(skHex, norm.NFC.Bytes([]byte(text)))
+}
+
+// VerifyText checks a SignText signature over the NFC normalisation of text.
+func VerifyText(pubKeyHex, sigHex, text string) (bool, error) {
+	return VerifySignature(pubKeyHex, sigHex, norm.NFC.String(text))
+}
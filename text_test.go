@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestTextNormalization(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	const composed = "caf\u00e9"    // é as one code point
+	const decomposed = "cafe\u0301" // e followed by a combining acute accent
+
+	sig, err := SignText(skHex, decomposed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyText(pubKeyHex, sig, composed); err != nil || !ok {
+		t.Fatalf("decomposed signature, composed text: ok=%v err=%v", ok, err)
+	}
+	sig, err = SignText(skHex, composed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyText(pubKeyHex, sig, decomposed); err != nil || !ok {
+		t.Fatalf("composed signature, decomposed text: ok=%v err=%v", ok, err)
+	}
+	// The byte-level functions do not normalise.
+	if ok, err := VerifySignature(pubKeyHex, mustSign(t, skHex, decomposed), composed); err != nil || ok {
+		t.Fatalf("VerifySignature normalised: ok=%v err=%v", ok, err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// scalarHex encodes x, reduced mod r, as a 32-byte big-endian secret key.
+func scalarHex(x *big.Int) string {
+	return EncodingEthereum.Encode(new(big.Int).Mod(x, curveOrder).FillBytes(make([]byte, 32)))
+}
+
+func TestVerifyThresholdReconstructed(t *testing.T) {
+	// Shamir-share a group key with threshold 2 of 3: f(x) = a0 + a1·x.
+	a0, err := rand.Int(rand.Reader, curveOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a1, err := rand.Int(rand.Reader, curveOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	share := func(x int64) string {
+		return scalarHex(new(big.Int).Add(a0, new(big.Int).Mul(a1, big.NewInt(x))))
+	}
+	groupSk := scalarHex(a0)
+	groupPk := mustPubKey(t, groupSk)
+
+	// Combine the partial signatures of members 1 and 3 with Lagrange
+	// coefficients at zero: λ1 = 3/2, λ3 = -1/2.
+	msg := "checkpoint"
+	two := new(big.Int).ModInverse(big.NewInt(2), curveOrder)
+	lambdas := map[int64]*big.Int{
+		1: new(big.Int).Mul(big.NewInt(3), two),
+		3: new(big.Int).Neg(two),
+	}
+	var combined blst.P2
+	for x, lambda := range lambdas {
+		b, err := decodeHex(mustSign(t, share(x), msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, _ := decodeHex(scalarHex(lambda))
+		var p blst.P2
+		p.FromAffine(new(blst.P2Affine).Uncompress(b))
+		combined.AddAssign(p.Mult(new(blst.Scalar).Deserialize(l)))
+	}
+	combinedHex := EncodingEthereum.Encode(combined.ToAffine().Compress())
+
+	if ok, err := VerifyThreshold(groupPk, combinedHex, msg); err != nil || !ok {
+		t.Fatalf("reconstructed signature: %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyThreshold(groupPk, mustSign(t, share(1), msg), msg); err != nil || ok {
+		t.Fatalf("single partial signature: %v, %v; want false", ok, err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// tokenAlg identifies compact tokens signed by this package.
+const tokenAlg = "BLS12381G2"
+
+var ErrInvalidToken = errors.New("invalid compact token")
+
+// tokenDomain separates token signatures from signatures over ordinary messages.
+var tokenDomain = []byte("bls-sig compact token")
+
+func tokenRoot(signed string) [32]byte {
+	return BuildSigningInput(tokenDomain, []byte(signed))
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// IssueCompactToken signs claims into a JWT-like token
+// base64url(header).base64url(payload).base64url(signature), where the header
+// names the signing key as kid and the signature covers "header.payload".
+func IssueCompactToken(skHex, kid string, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(tokenHeader{Alg: tokenAlg, Kid: kid})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sigHex, err := signRoot(skHex, tokenRoot(signed))
+	if err != nil {
+		return "", err
+	}
+	sig, err := decodeHex(sigHex)
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// VerifyCompactToken checks a token from IssueCompactToken, resolving the
+// header's kid to a public key with pubKeyResolver, and returns the claims
+// only if the signature is valid. It does not interpret claims such as exp;
+// that is left to the caller.
+func VerifyCompactToken(token string, pubKeyResolver func(kid string) (string, error)) (claims map[string]interface{}, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: want 3 parts, got %d", ErrInvalidToken, len(parts))
+	}
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	var header tokenHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	if header.Alg != tokenAlg {
+		return nil, fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	pubKeyHex, err := pubKeyResolver(header.Kid)
+	if err != nil {
+		return nil, fmt.Errorf("resolve kid %q: %w", header.Kid, err)
+	}
+	ok, err := verifyRoot(pubKeyHex, EncodingEthereum.Encode(sig), tokenRoot(parts[0]+"."+parts[1]))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: payload: %v", ErrInvalidToken, err)
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: payload: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCompactToken(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	resolve := func(kid string) (string, error) {
+		if kid != "key-1" {
+			return "", errors.New("unknown kid")
+		}
+		return pubKeyHex, nil
+	}
+	token, err := IssueCompactToken(skHex, "key-1", map[string]interface{}{"sub": "alice", "admin": false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := VerifyCompactToken(token, resolve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims["sub"] != "alice" || claims["admin"] != false {
+		t.Fatalf("claims = %v", claims)
+	}
+
+	parts := strings.Split(token, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"admin":true,"sub":"alice"}`))
+	if _, err := VerifyCompactToken(strings.Join(parts, "."), resolve); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("tampered payload: err = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxVanityPrefix caps the prefix length; each extra hex digit multiplies the
+// expected number of attempts by 16.
+const maxVanityPrefix = 6
+
+var (
+	ErrInvalidVanityPrefix = errors.New("invalid vanity prefix")
+	ErrVanityNotFound      = errors.New("no key matched the vanity prefix")
+)
+
+// GenerateVanityKeyPair generates key pairs until the public key hex starts
+// with prefix, giving up after maxAttempts. prefix may carry a 0x prefix and
+// is matched case-insensitively. The attempt count is returned on success and
+// failure.
+//
+// A compressed public key always has its top bit set, so prefixes must start
+// with 8, 9, a or b.
+func GenerateVanityKeyPair(prefix string, maxAttempts int) (string, string, int, error) {
+	prefix = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(prefix, "0x"), "0X"))
+	if err := checkVanityPrefix(prefix); err != nil {
+		return "", "", 0, err
+	}
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		skHex, pubHex, err := GenerateKeyPair()
+		if err != nil {
+			return "", "", attempt, err
+		}
+		if strings.HasPrefix(pubHex[2:], prefix) {
+			return skHex, pubHex, attempt, nil
+		}
+	}
+	return "", "", maxAttempts, fmt.Errorf("%w after %d attempts", ErrVanityNotFound, maxAttempts)
+}
+
+func checkVanityPrefix(prefix string) error {
+	if len(prefix) == 0 || len(prefix) > maxVanityPrefix {
+		return fmt.Errorf("%w: must be 1 to %d hex digits", ErrInvalidVanityPrefix, maxVanityPrefix)
+	}
+	if _, err := hex.DecodeString(prefix + strings.Repeat("0", len(prefix)%2)); err != nil {
+		return fmt.Errorf("%w: not hex", ErrInvalidVanityPrefix)
+	}
+	if !strings.ContainsRune("89ab", rune(prefix[0])) {
+		return fmt.Errorf("%w: compressed public keys start with 8, 9, a or b", ErrInvalidVanityPrefix)
+	}
+	return nil
+}
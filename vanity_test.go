@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateVanityKeyPair(t *testing.T) {
+	skHex, pubKeyHex, attempts, err := GenerateVanityKeyPair("0xA", 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(pubKeyHex, "0xa") {
+		t.Fatalf("public key %s does not start with a", pubKeyHex)
+	}
+	if attempts < 1 || attempts > 200 {
+		t.Fatalf("attempts = %d, want 1..200", attempts)
+	}
+	if mustPubKey(t, skHex) != pubKeyHex {
+		t.Fatal("public key does not match secret key")
+	}
+
+	for _, prefix := range []string{"", "1", "8zz", "8888888"} {
+		if _, _, _, err := GenerateVanityKeyPair(prefix, 1); !errors.Is(err, ErrInvalidVanityPrefix) {
+			t.Errorf("prefix %q: err = %v, want ErrInvalidVanityPrefix", prefix, err)
+		}
+	}
+}
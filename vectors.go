@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// testVectorDomain separates test-vector key material from any other use of seed.
+var testVectorDomain = []byte("bls-sig test vectors")
+
+// TestVector is one deterministic signing example. Signature is SecretKey's
+// signature over Message as GenerateSignature makes it, and Aggregate is the
+// aggregate of the signatures of this vector and every one before it, which
+// verifies with AggregateVerify over the matching keys and messages.
+type TestVector struct {
+	SecretKey string `json:"secret_key"`
+	PublicKey string `json:"pubkey"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+	Aggregate string `json:"aggregate"`
+}
+
+// GenerateTestVectors derives count vectors from seed. The same seed always
+// gives the same vectors, so other implementations can check against them.
+// The secret keys are derived from seed and must be treated as public.
+func GenerateTestVectors(count int, seed []byte) ([]TestVector, error) {
+	if count <= 0 {
+		return nil, ErrInvalidKeyCount
+	}
+	vectors := make([]TestVector, count)
+	sigs := make([]string, 0, count)
+	for i := range vectors {
+		var idx [8]byte
+		binary.BigEndian.PutUint64(idx[:], uint64(i))
+		ikm := BuildSigningInput(testVectorDomain, seed, idx[:])
+		sk := blst.KeyGen(ikm[:])
+		skHex := EncodingEthereum.Encode(sk.Serialize())
+		pubHex := EncodingEthereum.Encode(new(blst.P1Affine).From(sk).Compress())
+		sk.Zeroize()
+
+		msg := fmt.Sprintf("bls-sig test vector %d", i)
+		sigHex, err := GenerateSignature(skHex, []byte(msg))
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sigHex)
+		agg, err := AggregateSignatures(sigs)
+		if err != nil {
+			return nil, err
+		}
+		vectors[i] = TestVector{
+			SecretKey: skHex,
+			PublicKey: pubHex,
+			Message:   msg,
+			Signature: sigHex,
+			Aggregate: agg,
+		}
+	}
+	return vectors, nil
+}
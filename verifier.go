@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultNegativeTTL is how long a Verifier remembers a failed verification.
+const DefaultNegativeTTL = time.Minute
+
+// maxCachedResults bounds the result cache; it is cleared when full.
+const maxCachedResults = 1 << 16
+
+// maxLatencySamples bounds the latencies a Verifier keeps; once full, each new
+// sample overwrites the oldest.
+const maxLatencySamples = 4096
+
+// Verifier wraps VerifySignature, records how long each call takes and caches
+// results per (public key, signature, message). Valid results are kept until
+// the cache fills; invalid ones expire after the negative TTL, so a failure
+// caused by a transient issue upstream is not remembered forever. The zero
+// Verifier is ready to use, with negative caching off.
+type Verifier struct {
+	mu          sync.Mutex
+	latencies   []time.Duration // ring buffer of the latest samples
+	nextLatency int             // slot the next sample overwrites once full
+	results     map[[32]byte]cachedResult
+	negativeTTL time.Duration
+	stats       CacheStats
+}
+
+type cachedResult struct {
+	valid   bool
+	expires time.Time // zero for valid results
+}
+
+// CacheStats counts Verifier cache hits, split by cached outcome.
+type CacheStats struct {
+	PositiveHits uint64 `json:"positive_hits"`
+	NegativeHits uint64 `json:"negative_hits"`
+}
+
+// NewVerifier returns a Verifier with an empty cache and no recorded latencies.
+func NewVerifier() *Verifier {
+	return &Verifier{
+		results:     make(map[[32]byte]cachedResult),
+		negativeTTL: DefaultNegativeTTL,
+	}
+}
+
+// SetNegativeTTL sets how long failed verifications are cached. Zero disables
+// negative caching.
+func (v *Verifier) SetNegativeTTL(d time.Duration) {
+	v.mu.Lock()
+	v.negativeTTL = d
+	v.mu.Unlock()
+}
+
+// Verify is VerifySignature, timed and cached. Malformed input returns an
+// error and is never cached; only real verifications are timed.
+func (v *Verifier) Verify(pubKeyHex, sigHex, msg string) (bool, error) {
+	key := resultKey(pubKeyHex, sigHex, msg)
+	if valid, ok := v.lookup(key); ok {
+		return valid, nil
+	}
+	start := time.Now()
+	valid, err := VerifySignature(pubKeyHex, sigHex, msg)
+	if err != nil {
+		return false, err
+	}
+	v.record(time.Since(start))
+	v.store(key, valid)
+	return valid, nil
+}
+
+// WarmCache verifies entries and caches the results, so that the first real
+// requests for them are cache hits; it is meant for tuples reloaded at
+// start-up. It returns how many entries were cached and how many of those were
+// invalid. Malformed entries are skipped and reported in the joined error.
+// Warming is neither timed nor counted as cache hits.
+func (v *Verifier) WarmCache(entries []VerifyTuple) (warmed, invalid int, err error) {
+	var errs []error
+	for i, e := range entries {
+		valid, err := VerifySignature(e.PubKey, e.Signature, e.Message)
+		if err != nil {
+			errs = append(errs, batchElementError(i, err))
+			continue
+		}
+		v.store(resultKey(e.PubKey, e.Signature, e.Message), valid)
+		warmed++
+		if !valid {
+			invalid++
+		}
+	}
+	return warmed, invalid, errors.Join(errs...)
+}
+
+func resultKey(pubKeyHex, sigHex, msg string) [32]byte {
+	return BuildSigningInput([]byte(pubKeyHex), []byte(sigHex), []byte(msg))
+}
+
+func (v *Verifier) lookup(key [32]byte) (valid, ok bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	r, ok := v.results[key]
+	if !ok {
+		return false, false
+	}
+	if r.valid {
+		v.stats.PositiveHits++
+		return true, true
+	}
+	if time.Now().After(r.expires) {
+		delete(v.results, key)
+		return false, false
+	}
+	v.stats.NegativeHits++
+	return false, true
+}
+
+func (v *Verifier) store(key [32]byte, valid bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	r := cachedResult{valid: valid}
+	if !valid {
+		if v.negativeTTL <= 0 {
+			return
+		}
+		r.expires = time.Now().Add(v.negativeTTL)
+	}
+	if v.results == nil {
+		v.results = make(map[[32]byte]cachedResult)
+	} else if len(v.results) >= maxCachedResults {
+		clear(v.results)
+	}
+	v.results[key] = r
+}
+
+// CacheStats returns the cache hit counters.
+func (v *Verifier) CacheStats() CacheStats {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.stats
+}
+
+func (v *Verifier) record(d time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.latencies) < maxLatencySamples {
+		v.latencies = append(v.latencies, d)
+		return
+	}
+	v.latencies[v.nextLatency] = d
+	v.nextLatency = (v.nextLatency + 1) % maxLatencySamples
+}
+
+// LatencyStats summarises verification latencies. Durations marshal to JSON
+// as integer nanoseconds.
+type LatencyStats struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min_ns"`
+	Max   time.Duration `json:"max_ns"`
+	Mean  time.Duration `json:"mean_ns"`
+	P50   time.Duration `json:"p50_ns"`
+	P95   time.Duration `json:"p95_ns"`
+	P99   time.Duration `json:"p99_ns"`
+}
+
+// LatencyStats summarises the latest maxLatencySamples latencies recorded
+// since the Verifier was created or last reset. Percentiles use the
+// nearest-rank method.
+func (v *Verifier) LatencyStats() LatencyStats {
+	v.mu.Lock()
+	ds := append([]time.Duration(nil), v.latencies...)
+	v.mu.Unlock()
+	if len(ds) == 0 {
+		return LatencyStats{}
+	}
+
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	var total time.Duration
+	for _, d := range ds {
+		total += d
+	}
+	return LatencyStats{
+		Count: len(ds),
+		Min:   ds[0],
+		Max:   ds[len(ds)-1],
+		Mean:  total / time.Duration(len(ds)),
+		P50:   nearestRank(ds, 50),
+		P95:   nearestRank(ds, 95),
+		P99:   nearestRank(ds, 99),
+	}
+}
+
+// ResetLatencyStats discards all recorded latencies.
+func (v *Verifier) ResetLatencyStats() {
+	v.mu.Lock()
+	v.latencies, v.nextLatency = nil, 0
+	v.mu.Unlock()
+}
+
+// nearestRank returns the p-th percentile of the sorted, non-empty ds.
+func nearestRank(ds []time.Duration, p int) time.Duration {
+	rank := (p*len(ds) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return ds[rank-1]
+}
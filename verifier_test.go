@@ -0,0 +1,136 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyStats(t *testing.T) {
+	v := NewVerifier()
+	for i := 1; i <= 100; i++ {
+		v.record(time.Duration(i) * time.Millisecond)
+	}
+	want := LatencyStats{
+		Count: 100,
+		Min:   time.Millisecond,
+		Max:   100 * time.Millisecond,
+		Mean:  50500 * time.Microsecond,
+		P50:   50 * time.Millisecond,
+		P95:   95 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+	}
+	if got := v.LatencyStats(); got != want {
+		t.Fatalf("LatencyStats = %+v, want %+v", got, want)
+	}
+	v.ResetLatencyStats()
+	if got := v.LatencyStats(); got != (LatencyStats{}) {
+		t.Fatalf("after reset: %+v, want zero", got)
+	}
+}
+
+func TestLatencyStatsBounded(t *testing.T) {
+	v := NewVerifier()
+	for i := 0; i < maxLatencySamples+10; i++ {
+		v.record(time.Duration(i) * time.Microsecond)
+	}
+	got := v.LatencyStats()
+	if got.Count != maxLatencySamples {
+		t.Fatalf("Count = %d, want %d", got.Count, maxLatencySamples)
+	}
+	if got.Min != 10*time.Microsecond {
+		t.Fatalf("Min = %v, want the 10 oldest samples evicted", got.Min)
+	}
+	if len(v.latencies) != maxLatencySamples {
+		t.Fatalf("kept %d samples, want %d", len(v.latencies), maxLatencySamples)
+	}
+}
+
+func TestVerifierNegativeCache(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "signed")
+	v := NewVerifier()
+	for i := 0; i < 2; i++ {
+		if ok, err := v.Verify(pubKeyHex, sig, "not signed"); err != nil || ok {
+			t.Fatalf("bad tuple, call %d: %v, %v; want false", i, ok, err)
+		}
+	}
+	if got, want := v.CacheStats(), (CacheStats{NegativeHits: 1}); got != want {
+		t.Fatalf("CacheStats = %+v, want %+v", got, want)
+	}
+	if got := v.LatencyStats().Count; got != 1 {
+		t.Fatalf("%d verifications timed, want only the first", got)
+	}
+
+	v.SetNegativeTTL(time.Nanosecond)
+	if _, err := v.Verify(pubKeyHex, sig, "also not signed"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if _, err := v.Verify(pubKeyHex, sig, "also not signed"); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.CacheStats().NegativeHits; got != 1 {
+		t.Fatalf("expired negative result was a hit: %d negative hits", got)
+	}
+}
+
+func TestZeroVerifier(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "signed")
+	var v Verifier
+	for i := 0; i < 2; i++ {
+		if ok, err := v.Verify(pubKeyHex, sig, "signed"); err != nil || !ok {
+			t.Fatalf("call %d: %v, %v; want true", i, ok, err)
+		}
+	}
+	if got := v.CacheStats().PositiveHits; got != 1 {
+		t.Fatalf("PositiveHits = %d, want 1", got)
+	}
+}
+
+func TestWarmCache(t *testing.T) {
+	entries := testTuples(t, 3)
+	entries[2].Message = "tampered"
+	malformed := VerifyTuple{PubKey: "0x00", Signature: entries[0].Signature, Message: "x"}
+
+	v := NewVerifier()
+	warmed, invalid, err := v.WarmCache(append(entries, malformed))
+	if err == nil {
+		t.Fatal("malformed entry not reported")
+	}
+	if warmed != 3 || invalid != 1 {
+		t.Fatalf("warmed %d, invalid %d; want 3, 1", warmed, invalid)
+	}
+	if got := v.LatencyStats().Count; got != 0 {
+		t.Fatalf("warming recorded %d latencies, want 0", got)
+	}
+	for i, e := range entries {
+		if ok, err := v.Verify(e.PubKey, e.Signature, e.Message); err != nil || ok != (i != 2) {
+			t.Fatalf("entry %d: %v, %v", i, ok, err)
+		}
+	}
+	if got, want := v.CacheStats(), (CacheStats{PositiveHits: 2, NegativeHits: 1}); got != want {
+		t.Fatalf("CacheStats = %+v, want %+v", got, want)
+	}
+	if got := v.LatencyStats().Count; got != 0 {
+		t.Fatalf("warmed tuples were re-verified %d times, want 0", got)
+	}
+}
+
+func TestVerifierMalformedNotTimed(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "signed")
+	v := NewVerifier()
+	if _, err := v.Verify(pubKeyHex, sig, "signed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify("0xzz", sig, "signed"); err == nil {
+		t.Fatal("malformed public key accepted")
+	}
+	if _, err := v.Verify(pubKeyHex, sig[:len(sig)-2], "signed"); err == nil {
+		t.Fatal("short signature accepted")
+	}
+	if got := v.LatencyStats().Count; got != 1 {
+		t.Fatalf("Count = %d after one real and two malformed calls, want 1", got)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+)
+
+func signatureFromHex(sigHex string) (common.Signature, error) {
+	b, err := decodeHex(sigHex)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	return SignatureFromBytes(b)
+}
+
+func publicKeysFromHex(pubKeyHexes []string) ([]common.PublicKey, error) {
+	pks := make([]common.PublicKey, len(pubKeyHexes))
+	for i, h := range pubKeyHexes {
+		pk, err := publicKeyFromHex(h)
+		if err != nil {
+			return nil, fmt.Errorf("public key %d: %w", i, err)
+		}
+		pks[i] = pk
+	}
+	return pks, nil
+}
+
+// VerifySignature checks sigHex over the SHA-256 of msg against pubKeyHex.
+func VerifySignature(pubKeyHex, sigHex, msg string) (bool, error) {
+	if err := checkMessageSize(len(msg)); err != nil {
+		return false, err
+	}
+	return verifyRoot(pubKeyHex, sigHex, signingRoot([]byte(msg)))
+}
+
+// verifyRoot checks sigHex over an already computed 32-byte signing root.
+func verifyRoot(pubKeyHex, sigHex string, root [32]byte) (bool, error) {
+	pk, err := publicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return false, err
+	}
+	sig, err := signatureFromHex(sigHex)
+	if err != nil {
+		return false, err
+	}
+	return sig.Verify(pk, root[:]), nil
+}
+
+// VerifyManyMessagesOneKey checks sigHexes[i] over msgs[i] against a single
+// public key, decoding the key only once. A malformed signature is reported as
+// invalid at its index rather than failing the whole call.
+func VerifyManyMessagesOneKey(pubKeyHex string, sigHexes []string, msgs []string) ([]bool, error) {
+	if len(sigHexes) != len(msgs) {
+		return nil, fmt.Errorf("%w: %d signatures, %d messages", ErrLengthMismatch, len(sigHexes), len(msgs))
+	}
+	pk, err := publicKeyFromHex(pubKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]bool, len(sigHexes))
+	for i, h := range sigHexes {
+		sig, err := signatureFromHex(h)
+		if err != nil {
+			continue
+		}
+		root := signingRoot([]byte(msgs[i]))
+		results[i] = sig.Verify(pk, root[:])
+	}
+	return results, nil
+}
+
+// VerifySignatureChunks reassembles a signature split across hex chunks and
+// verifies it like VerifySignature. The reassembled signature must be exactly
+// 96 bytes.
+func VerifySignatureChunks(pubKeyHex string, sigChunks []string, msg string) (bool, error) {
+	var sig []byte
+	for i, c := range sigChunks {
+		b, err := decodeHex(c)
+		if err != nil {
+			return false, fmt.Errorf("signature chunk %d: %w", i, err)
+		}
+		sig = append(sig, b...)
+	}
+	if err := checkSignatureLength(sig, SignatureLength); err != nil {
+		return false, err
+	}
+	return VerifySignature(pubKeyHex, EncodingEthereum.Encode(sig), msg)
+}
+
+// VerifyLegacyTruncated checks a signature made by the original
+// GenerateSignature, which signed msg copied into 32 bytes: longer messages
+// were cut off and shorter ones zero-padded. It exists only so stored
+// signatures can still be checked during migration.
+//
+// Deprecated: messages that share their first 32 bytes are indistinguishable
+// under this scheme. Re-sign with GenerateSignature and use VerifySignature.
+func VerifyLegacyTruncated(pubKeyHex, sigHex, msg string) (bool, error) {
+	var root [32]byte
+	copy(root[:], msg)
+	return verifyRoot(pubKeyHex, sigHex, root)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyManyMessagesOneKey(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	otherSK, _ := testKeyPair(t)
+	sigs := []string{
+		mustSign(t, skHex, "m0"),
+		mustSign(t, skHex, "not m1"),
+		mustSign(t, otherSK, "m2"),
+		"0x1234",
+		mustSign(t, skHex, "m4"),
+	}
+	msgs := []string{"m0", "m1", "m2", "m3", "m4"}
+	got, err := VerifyManyMessagesOneKey(pubKeyHex, sigs, msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []bool{true, false, false, false, true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("results = %v, want %v", got, want)
+	}
+	if _, err := VerifyManyMessagesOneKey(pubKeyHex, sigs, msgs[:4]); err == nil {
+		t.Fatal("mismatched lengths accepted")
+	}
+}
+
+func TestVerifySignatureChunks(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	sig := mustSign(t, skHex, "chunked")
+	raw := sig[2:]
+	halves := []string{raw[:96], raw[96:]}
+	if ok, err := VerifySignatureChunks(pubKeyHex, halves, "chunked"); err != nil || !ok {
+		t.Fatalf("two halves: %v, %v; want true", ok, err)
+	}
+	if _, err := VerifySignatureChunks(pubKeyHex, halves[:1], "chunked"); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("one half: err = %v, want ErrInvalidSignature", err)
+	}
+	if _, err := VerifySignatureChunks(pubKeyHex, append(halves, "00"), "chunked"); !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("extra chunk: err = %v, want ErrTrailingBytes", err)
+	}
+}
+
+func TestVerifyLegacyTruncated(t *testing.T) {
+	skHex, pubKeyHex := testKeyPair(t)
+	msg := "a message longer than the thirty-two bytes the old code kept"
+
+	// The original GenerateSignature signed msg copied into 32 bytes.
+	var truncated [32]byte
+	copy(truncated[:], msg)
+	legacySig, err := signRoot(skHex, truncated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := VerifyLegacyTruncated(pubKeyHex, legacySig, msg); err != nil || !ok {
+		t.Fatalf("legacy signature: ok=%v err=%v", ok, err)
+	}
+	// Only the first 32 bytes were ever signed.
+	if ok, err := VerifyLegacyTruncated(pubKeyHex, legacySig, msg[:32]+"different tail"); err != nil || !ok {
+		t.Fatalf("same prefix: ok=%v err=%v", ok, err)
+	}
+	if ok, err := VerifySignature(pubKeyHex, legacySig, msg); err != nil || ok {
+		t.Fatalf("legacy signature on hashing path: ok=%v err=%v", ok, err)
+	}
+	if ok, err := VerifyLegacyTruncated(pubKeyHex, mustSign(t, skHex, msg), msg); err != nil || ok {
+		t.Fatalf("hashed signature on legacy path: ok=%v err=%v", ok, err)
+	}
+}
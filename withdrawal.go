@@ -0,0 +1,67 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// DomainBLSToExecutionChange is DOMAIN_BLS_TO_EXECUTION_CHANGE.
+var DomainBLSToExecutionChange = [4]byte{0x0a, 0x00, 0x00, 0x00}
+
+// MainnetGenesisForkVersion is mainnet's GENESIS_FORK_VERSION. Per the
+// Capella spec a BLSToExecutionChange domain always uses the genesis version,
+// whatever the current fork.
+var MainnetGenesisForkVersion = [4]byte{0x00, 0x00, 0x00, 0x00}
+
+// blsToExecutionChangeRoot is hash_tree_root(BLSToExecutionChange{...}): the
+// three fields are merkleized as four 32-byte leaves, the last one zero.
+func blsToExecutionChangeRoot(validatorIndex uint64, fromBLSPubKey [48]byte, toExecutionAddress [20]byte) [32]byte {
+	var index, pubKeyChunks [64]byte
+	binary.LittleEndian.PutUint64(index[:8], validatorIndex)
+	copy(pubKeyChunks[:], fromBLSPubKey[:])
+	pubKeyRoot := sha256.Sum256(pubKeyChunks[:])
+	copy(index[32:], pubKeyRoot[:])
+	left := sha256.Sum256(index[:])
+
+	var address [64]byte
+	copy(address[:20], toExecutionAddress[:])
+	right := sha256.Sum256(address[:])
+
+	var node [64]byte
+	copy(node[:32], left[:])
+	copy(node[32:], right[:])
+	return sha256.Sum256(node[:])
+}
+
+func blsToExecutionChangeSigningRoot(genesisForkVersion [4]byte, validatorIndex uint64, fromBLSPubKey [48]byte, toExecutionAddress [20]byte, genesisValidatorsRoot [32]byte) [32]byte {
+	domain := computeDomain(DomainBLSToExecutionChange, genesisForkVersion, genesisValidatorsRoot)
+	return computeForkSigningRoot(blsToExecutionChangeRoot(validatorIndex, fromBLSPubKey, toExecutionAddress), domain)
+}
+
+// SignBLSToExecutionChange signs a Capella BLSToExecutionChange moving a
+// validator's withdrawal credentials from fromBLSPubKey to toExecutionAddress.
+// skHex must be the withdrawal key behind fromBLSPubKey. The domain uses
+// MainnetGenesisForkVersion; see SignBLSToExecutionChangeForNetwork.
+func SignBLSToExecutionChange(skHex string, validatorIndex uint64, fromBLSPubKey [48]byte, toExecutionAddress [20]byte, genesisValidatorsRoot [32]byte) (string, error) {
+	return SignBLSToExecutionChangeForNetwork(skHex, MainnetGenesisForkVersion, validatorIndex, fromBLSPubKey, toExecutionAddress, genesisValidatorsRoot)
+}
+
+// SignBLSToExecutionChangeForNetwork is SignBLSToExecutionChange for a
+// network with the given genesis fork version.
+func SignBLSToExecutionChangeForNetwork(skHex string, genesisForkVersion [4]byte, validatorIndex uint64, fromBLSPubKey [48]byte, toExecutionAddress [20]byte, genesisValidatorsRoot [32]byte) (string, error) {
+	return signRoot(skHex, blsToExecutionChangeSigningRoot(genesisForkVersion, validatorIndex, fromBLSPubKey, toExecutionAddress, genesisValidatorsRoot))
+}
+
+// VerifyBLSToExecutionChange checks a SignBLSToExecutionChange signature
+// against fromBLSPubKey, as the beacon chain does, using
+// MainnetGenesisForkVersion.
+func VerifyBLSToExecutionChange(sigHex string, validatorIndex uint64, fromBLSPubKey [48]byte, toExecutionAddress [20]byte, genesisValidatorsRoot [32]byte) (bool, error) {
+	return VerifyBLSToExecutionChangeForNetwork(sigHex, MainnetGenesisForkVersion, validatorIndex, fromBLSPubKey, toExecutionAddress, genesisValidatorsRoot)
+}
+
+// VerifyBLSToExecutionChangeForNetwork is VerifyBLSToExecutionChange for a
+// network with the given genesis fork version.
+func VerifyBLSToExecutionChangeForNetwork(sigHex string, genesisForkVersion [4]byte, validatorIndex uint64, fromBLSPubKey [48]byte, toExecutionAddress [20]byte, genesisValidatorsRoot [32]byte) (bool, error) {
+	root := blsToExecutionChangeSigningRoot(genesisForkVersion, validatorIndex, fromBLSPubKey, toExecutionAddress, genesisValidatorsRoot)
+	return verifyRoot(EncodingEthereum.Encode(fromBLSPubKey[:]), sigHex, root)
+}
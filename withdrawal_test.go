@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+// The expected root and signature were produced by prysm v5's
+// signing.ComputeSigningRoot over an ethpb.BLSToExecutionChange with the
+// mainnet DOMAIN_BLS_TO_EXECUTION_CHANGE and GENESIS_FORK_VERSION, signed
+// with the example key.
+const (
+	wantBLSChangeRoot = "0xf831d84d03cef86cfc1185f498088dedfd8dd83bbe224eff49646685c7f31e3f"
+	wantBLSChangeSig  = "0xb568a5a996c6776fd05c3c46d9554f2afc3c935d1ee95e22bd861ff017879f96c9c7013e94448237db9c98d3f27d6ef0109f6838d697784ed9e034aa8bd38ee3ea17b38bd4581aae81847239c20ce74606f324982d25a9edb837ba1e26151c36"
+)
+
+func TestBLSToExecutionChangeVector(t *testing.T) {
+	skHex, pubKeyHex := ExampleKeyPair()
+	var from [48]byte
+	b, _ := decodeHex(pubKeyHex)
+	copy(from[:], b)
+	var to [20]byte
+	for i := range to {
+		to[i] = byte(i + 1)
+	}
+	gvr := [32]byte{0x4b, 0x36, 0x3d, 0xb9}
+
+	root := blsToExecutionChangeSigningRoot(MainnetGenesisForkVersion, 42, from, to, gvr)
+	if got := EncodingEthereum.Encode(root[:]); got != wantBLSChangeRoot {
+		t.Fatalf("signing root %s, want %s", got, wantBLSChangeRoot)
+	}
+	sig, err := SignBLSToExecutionChange(skHex, 42, from, to, gvr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig != wantBLSChangeSig {
+		t.Fatalf("signature %s, want %s", sig, wantBLSChangeSig)
+	}
+	if ok, err := VerifyBLSToExecutionChange(sig, 42, from, to, gvr); err != nil || !ok {
+		t.Fatalf("VerifyBLSToExecutionChange = %v, %v; want true", ok, err)
+	}
+	if ok, err := VerifyBLSToExecutionChange(sig, 43, from, to, gvr); err != nil || ok {
+		t.Fatalf("other validator index: %v, %v; want false", ok, err)
+	}
+	holesky := [4]byte{0x01, 0x01, 0x70, 0x00}
+	if ok, err := VerifyBLSToExecutionChangeForNetwork(sig, holesky, 42, from, to, gvr); err != nil || ok {
+		t.Fatalf("other network: %v, %v; want false", ok, err)
+	}
+}